@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/opslevel/opslevel-go/v2025"
 
 	"github.com/opslevel/cli/common"
@@ -19,15 +16,7 @@ var lifecycleCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		list, err := getClientGQL().ListLifecycles()
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t\n", item.Alias, item.Id)
-			}
-			w.Flush()
-		}
+		printList(list, common.NewColumn("ALIAS", "alias"), common.NewColumn("ID", "id"))
 	},
 }
 
@@ -39,15 +28,7 @@ var tierCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		list, err := getClientGQL().ListTiers()
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t\n", item.Alias, item.Id)
-			}
-			w.Flush()
-		}
+		printList(list, common.NewColumn("ALIAS", "alias"), common.NewColumn("ID", "id"))
 	},
 }
 
@@ -57,16 +38,7 @@ var toolsCmd = &cobra.Command{
 	Short:   "Lists the valid alias for tools",
 	Long:    `Lists the valid alias for tools`,
 	Run: func(cmd *cobra.Command, args []string) {
-		list := opslevel.AllToolCategory
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t\n", item)
-			}
-			w.Flush()
-		}
+		printList(opslevel.AllToolCategory, columnOf("NAME", func(item string) string { return item }))
 	},
 }
 
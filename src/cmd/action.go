@@ -85,7 +85,7 @@ var getActionCmd = &cobra.Command{
 		key := args[0]
 		action, err := getClientGQL().GetCustomAction(key)
 		cobra.CheckErr(err)
-		common.PrettyPrint(action)
+		printResult(action)
 	},
 }
 
@@ -97,16 +97,12 @@ var listActionCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListCustomActions(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ID", "NAME", "HTTP_METHOD", "WEBHOOK_URL")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", string(item.CustomActionsId.Id), item.Name, item.HttpMethod, item.WebhookUrl)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("ID", "id"),
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("HTTP_METHOD", "httpMethod"),
+			common.NewColumn("WEBHOOK_URL", "webhookUrl"),
+		)
 	},
 }
 
@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var applyFiles []string
+
+var applyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "Create or update resources from manifest files",
+	Long: `Create or update resources from one or more multi-document YAML manifest files.
+
+Each document describes a single resource and is matched to an existing resource by its
+'alias' (or 'spec.name' when no alias is given). Matching resources are updated and
+everything else is created. Resources are applied in dependency order so that a team
+is created before the services it owns and a parent team before its children.
+
+Supported kinds: team, property-definition, domain, system, service, infra, secret,
+filter, category, level, scorecard, action, trigger-definition and check. The spec of
+each kind is the same input used by the matching 'opslevel create' command. Checks may
+also be written in the format accepted by 'opslevel create check'.
+
+A resource that fails to apply doesn't stop the others, the number of resources created,
+updated and failed is printed at the end and the command exits non-zero if any failed.`,
+	Example: `
+		cat << EOF | opslevel apply -f -
+		version: "1"
+		kind: team
+		alias: platform
+		spec:
+		  name: Platform
+		  responsibilities: Builds the platform
+		---
+		version: "1"
+		kind: service
+		alias: shopping-cart
+		spec:
+		  name: Shopping Cart
+		  owner:
+		    alias: platform
+		EOF
+
+		opslevel apply -f ./manifests/ -f ./checks.yaml --dry-run
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		cobra.CheckErr(err)
+		manifests, err := readManifests(applyFiles...)
+		cobra.CheckErr(err)
+
+		client := getClientGQL()
+		var created, updated, failed int
+		for _, handler := range resourceHandlers {
+			var todo []*ResourceManifest
+			for i := range manifests {
+				if manifests[i].Kind == handler.kind {
+					todo = append(todo, &manifests[i])
+				}
+			}
+			if len(todo) == 0 {
+				continue
+			}
+			if handler.kind == ResourceKindTeam {
+				sortTeamManifests(todo)
+			}
+
+			index, err := handler.index(client)
+			if err != nil {
+				log.Error().Err(err).Msgf("unable to list existing %s resources", handler.kind)
+				failed += len(todo)
+				continue
+			}
+			for _, manifest := range todo {
+				key := manifest.Identifier()
+				id, exists := index[key]
+				switch {
+				case exists && dryRun:
+					fmt.Printf("%s '%s' would be updated\n", handler.kind, key)
+				case exists:
+					if err := wrapManifestErr(manifest, handler.update(client, id, manifest)); err != nil {
+						log.Error().Err(err).Msg("")
+						failed++
+						continue
+					}
+					fmt.Printf("%s '%s' updated\n", handler.kind, key)
+					updated++
+				case dryRun:
+					fmt.Printf("%s '%s' would be created\n", handler.kind, key)
+				default:
+					id, err = handler.create(client, manifest)
+					if err := wrapManifestErr(manifest, err); err != nil {
+						log.Error().Err(err).Msg("")
+						failed++
+						continue
+					}
+					index.add(id, key)
+					fmt.Printf("%s '%s' created\n", handler.kind, key)
+					created++
+				}
+			}
+		}
+		if dryRun {
+			return
+		}
+		fmt.Printf("%d created, %d updated, %d failed\n", created, updated, failed)
+		if failed > 0 {
+			cobra.CheckErr(fmt.Errorf("%d resource(s) failed to apply", failed))
+		}
+	},
+}
+
+func wrapManifestErr(manifest *ResourceManifest, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s: unable to apply %s '%s': %w", manifest.Source, manifest.Kind, manifest.Identifier(), err)
+}
+
+func init() {
+	rootCmd.AddCommand(applyCmd)
+
+	applyCmd.Flags().StringArrayVarP(&applyFiles, "file", "f", []string{"-"}, "File or directory of YAML manifests to apply, can be repeated. Defaults to reading from stdin.")
+	applyCmd.Flags().Bool("dry-run", false, "Print what would be created or updated without making any changes")
+}
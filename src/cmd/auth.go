@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"golang.org/x/term"
+)
+
+var authCmd = &cobra.Command{
+	Use:   "auth",
+	Short: "Manage the API token used to talk to OpsLevel",
+	Long: `Manage the API token used to talk to OpsLevel
+
+The API token is read from the first of these that is set:
+  1. the --api-token flag
+  2. the 'OPSLEVEL_API_TOKEN' environment variable
+  3. the output of the --api-token-command flag or 'OPSLEVEL_API_TOKEN_COMMAND' environment variable
+  4. the 'api-token' or 'api-token-command' of the profile in the config file
+  5. the token saved for the profile by 'opslevel auth login'`,
+}
+
+var authLoginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Validate an API token and save it for the profile",
+	Long: `Prompts for an API token, checks that it works against the OpsLevel API and saves it
+to a credentials file next to the config file that only the current user can read.
+
+The token is read from stdin without a prompt when stdin is not a terminal.`,
+	Example: `
+		opslevel auth login
+		opslevel auth login --profile sandbox --api-url https://app.opslevel.com
+		op read op://opslevel/token | opslevel auth login
+		`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, err := readTokenInput()
+		cobra.CheckErr(err)
+		account, err := queryAuthAccount(newAuthClient(token))
+		if err != nil {
+			cobra.CheckErr(fmt.Errorf("the API token was rejected by '%s': %w", viper.GetString("api-url"), err))
+		}
+
+		path, credentials := readCredentials()
+		credentials[activeProfileName] = token
+		cobra.CheckErr(credentials.Write(path))
+		fmt.Printf("logged in to account '%s' with profile '%s', the token was saved to '%s'\n", account.name(), activeProfileName, path)
+	},
+}
+
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the account, user and API token in use",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		token, source, err := resolveAPIToken()
+		cobra.CheckErr(err)
+		fmt.Printf("Profile:  %s\n", activeProfileName)
+		fmt.Printf("API URL:  %s\n", viper.GetString("api-url"))
+		if token == "" {
+			fmt.Println("Token:    none - run 'opslevel auth login'")
+			os.Exit(1)
+		}
+		fmt.Printf("Token:    %s from %s\n", maskToken(token), source)
+		account, err := queryAuthAccount(newAuthClient(token))
+		if err != nil {
+			fmt.Printf("Account:  the API token was rejected: %s\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Account:  %s\n", account.name())
+		fmt.Printf("User:     %s\n", account.CurrentUser.name())
+	},
+}
+
+var authLogoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Remove the API token saved for the profile",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, credentials := readCredentials()
+		if _, ok := credentials[activeProfileName]; !ok {
+			fmt.Printf("profile '%s' is not logged in\n", activeProfileName)
+			return
+		}
+		delete(credentials, activeProfileName)
+		cobra.CheckErr(credentials.Write(path))
+		fmt.Printf("logged out of profile '%s'\n", activeProfileName)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(authCmd)
+	authCmd.AddCommand(authLoginCmd)
+	authCmd.AddCommand(authStatusCmd)
+	authCmd.AddCommand(authLogoutCmd)
+}
+
+// resolveAPIToken finds the API token in the order documented on 'opslevel auth' and describes where it came from
+func resolveAPIToken() (string, string, error) {
+	if flag := rootCmd.PersistentFlags().Lookup("api-token"); flag.Changed {
+		return flag.Value.String(), "the --api-token flag", nil
+	}
+	for _, name := range []string{"OPSLEVEL_API_TOKEN", "OL_API_TOKEN", "OL_APITOKEN"} {
+		if value := os.Getenv(name); value != "" {
+			return value, fmt.Sprintf("the '%s' environment variable", name), nil
+		}
+	}
+	if command := viper.GetString("api-token-command"); command != "" {
+		token, err := common.RunTokenCommand(command)
+		return token, "the --api-token-command", err
+	}
+	if activeProfile.ApiToken != "" {
+		return activeProfile.ApiToken, fmt.Sprintf("the '%s' profile in the config file", activeProfileName), nil
+	}
+	if activeProfile.ApiTokenCommand != "" {
+		token, err := common.RunTokenCommand(activeProfile.ApiTokenCommand)
+		return token, fmt.Sprintf("the api-token-command of the '%s' profile", activeProfileName), err
+	}
+	path, credentials := readCredentials()
+	if token := credentials[activeProfileName]; token != "" {
+		return token, fmt.Sprintf("the credentials file '%s'", path), nil
+	}
+	return "", "", nil
+}
+
+func readCredentials() (string, common.Credentials) {
+	path, err := common.CredentialsPath()
+	cobra.CheckErr(err)
+	credentials, err := common.ReadCredentials(path)
+	cobra.CheckErr(err)
+	return path, credentials
+}
+
+func hasCredentials(profile string) bool {
+	_, credentials := readCredentials()
+	_, ok := credentials[profile]
+	return ok
+}
+
+// readTokenInput prompts for the token without echoing it or reads it from stdin when piped
+func readTokenInput() (string, error) {
+	var token string
+	fd := int(os.Stdin.Fd())
+	if term.IsTerminal(fd) {
+		fmt.Fprint(os.Stderr, "Paste your OpsLevel API token: ")
+		data, err := term.ReadPassword(fd)
+		fmt.Fprintln(os.Stderr)
+		if err != nil {
+			return "", err
+		}
+		token = string(data)
+	} else {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && err != io.EOF {
+			return "", err
+		}
+		token = line
+	}
+	token = strings.TrimSpace(token)
+	if token == "" {
+		return "", fmt.Errorf("no API token was given")
+	}
+	return token, nil
+}
+
+// newAuthClient is a client for the token that skips the validation done by getClientGQL
+func newAuthClient(token string) *opslevel.Client {
+	return opslevel.NewGQLClient(
+		opslevel.SetAPIToken(token),
+		opslevel.SetURL(viper.GetString("api-url")),
+		opslevel.SetTimeout(time.Second*time.Duration(viper.GetInt("api-timeout"))),
+		opslevel.SetUserAgentExtra(fmt.Sprintf("cli-%s", version)),
+	)
+}
+
+type authAccount struct {
+	Id          string   `json:"id"`
+	Name        string   `json:"name"`
+	CurrentUser authUser `json:"currentUser"`
+}
+
+// authUser is the user the API token acts as, tokens that don't belong to a user have none
+type authUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func (user authUser) name() string {
+	switch {
+	case user.Name != "" && user.Email != "":
+		return fmt.Sprintf("%s <%s>", user.Name, user.Email)
+	case user.Name != "" || user.Email != "":
+		return user.Name + user.Email
+	default:
+		return "none - the API token does not belong to a user"
+	}
+}
+
+func (account authAccount) name() string {
+	switch {
+	case account.Name != "":
+		return account.Name
+	case account.Id != "":
+		return account.Id
+	default:
+		return viper.GetString("api-url")
+	}
+}
+
+// queryAuthAccount checks the token and then reads the name of the account and user it belongs to
+//
+// The names are only used for display so a failure to read them is not an error once the token is valid.
+// The user is read in its own query so the account name is still shown when the user can't be read.
+func queryAuthAccount(client *opslevel.Client) (*authAccount, error) {
+	if err := client.Validate(); err != nil {
+		return nil, err
+	}
+	var response struct {
+		Account authAccount `json:"account"`
+	}
+	data, err := client.ExecRaw(`query { account { id name } }`, nil)
+	if err == nil {
+		_ = json.Unmarshal(data, &response)
+	}
+	data, err = client.ExecRaw(`query { account { currentUser { name email } } }`, nil)
+	if err == nil {
+		_ = json.Unmarshal(data, &response)
+	}
+	return &response.Account, nil
+}
+
+// maskToken shows just enough of the token to tell tokens apart
+func maskToken(token string) string {
+	if len(token) <= 8 {
+		return strings.Repeat("*", len(token))
+	}
+	return token[:4] + strings.Repeat("*", 8) + token[len(token)-4:]
+}
@@ -23,11 +23,7 @@ var getCheckCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		check, err := getClientGQL().GetCheck(opslevel.ID(args[0]))
 		cobra.CheckErr(err)
-		if isYamlOutput() {
-			common.YamlPrint(marshalCheck(*check))
-		} else {
-			common.PrettyPrint(marshalCheck(*check))
-		}
+		printResult(marshalCheck(*check))
 	},
 }
 
@@ -39,16 +35,14 @@ var listCheckCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListChecks(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "TYPE", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Type, item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("TYPE", "type"),
+			common.NewColumn("ID", "id"),
+			common.NewWideColumn("CATEGORY", "category.name"),
+			common.NewWideColumn("LEVEL", "level.name"),
+			common.NewWideColumn("ENABLED", "enabled"),
+		)
 	},
 }
 
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/opslevel/cli/common"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Manage the profiles in the config file",
+	Long: `Manage the profiles in the config file
+
+Profiles are read from '~/.config/opslevel/config.yaml' (or '$XDG_CONFIG_HOME/opslevel/config.yaml')
+and hold the settings for one OpsLevel account. The settings of the selected profile are used
+unless they are overridden by a flag or an environment variable.
+
+	current-profile: sandbox
+	profiles:
+	  sandbox:
+	    api-url: https://app.opslevel.com
+	    api-token-command: op read op://opslevel/sandbox/token
+	  production:
+	    api-token: XXX
+	    api-timeout: 30
+	    output: json
+	    github-url: https://github.example.com/api/v3
+	    gitlab-url: https://gitlab.example.com/api/v4
+
+Select a profile for a single command with --profile or the 'OPSLEVEL_PROFILE' environment variable.`,
+}
+
+var configUseProfileCmd = &cobra.Command{
+	Use:        "use-profile NAME",
+	Short:      "Set the profile used by default",
+	Args:       cobra.ExactArgs(1),
+	ArgAliases: []string{"NAME"},
+	Run: func(cmd *cobra.Command, args []string) {
+		name := args[0]
+		path, config := readConfig()
+		if _, ok := config.Profiles[name]; !ok {
+			cobra.CheckErr(fmt.Errorf("profile '%s' not found in '%s' - must be one of: %s", name, path, strings.Join(config.ProfileNames(), ", ")))
+		}
+		config.CurrentProfile = name
+		cobra.CheckErr(config.Write(path))
+		fmt.Printf("switched to profile '%s'\n", name)
+	},
+}
+
+var configListProfilesCmd = &cobra.Command{
+	Use:     "list-profiles",
+	Aliases: []string{"profiles"},
+	Short:   "List the profiles in the config file",
+	Run: func(cmd *cobra.Command, args []string) {
+		_, config := readConfig()
+		current := config.ProfileName(viper.GetString("profile"))
+		w := common.NewTabWriter("CURRENT", "NAME", "API URL")
+		for _, name := range config.ProfileNames() {
+			marker := ""
+			if name == current {
+				marker = "*"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t\n", marker, name, config.Profiles[name].ApiUrl)
+		}
+		w.Flush()
+	},
+}
+
+var configViewCmd = &cobra.Command{
+	Use:   "view",
+	Short: "Print the config file with the tokens redacted",
+	Run: func(cmd *cobra.Command, args []string) {
+		path, config := readConfig()
+		data, err := yaml.Marshal(config.Redacted())
+		cobra.CheckErr(err)
+		fmt.Printf("# %s\n%s", path, data)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configUseProfileCmd)
+	configCmd.AddCommand(configListProfilesCmd)
+	configCmd.AddCommand(configViewCmd)
+}
+
+var (
+	activeProfileName string
+	activeProfile     common.Profile
+	// activeProfileErr is set when --profile names an unknown profile, it is reported once a client is built
+	activeProfileErr error
+)
+
+func readConfig() (string, *common.Config) {
+	path, err := common.ConfigPath()
+	cobra.CheckErr(err)
+	config, err := common.ReadConfig(path)
+	cobra.CheckErr(err)
+	return path, config
+}
+
+// applyProfile makes the settings of the selected profile the defaults of the matching flags
+//
+// Flags and environment variables still take precedence since viper only falls back to defaults.
+// The token of the profile is used by resolveAPIToken so it can report where the token came from.
+// An unknown profile doesn't fail here so that 'config' and 'auth login --profile NEW' keep working.
+func applyProfile() {
+	path, config := readConfig()
+	name := viper.GetString("profile")
+	activeProfileName = config.ProfileName(name)
+	profile, ok := config.Profile(name)
+	activeProfile = profile
+	if !ok {
+		if name != "" && !hasCredentials(name) {
+			activeProfileErr = fmt.Errorf("profile '%s' not found in '%s' and has no saved credentials - run 'opslevel auth login --profile %s'", name, path, name)
+		}
+		return
+	}
+	if profile.ApiUrl != "" {
+		viper.SetDefault("api-url", profile.ApiUrl)
+	}
+	if profile.ApiTimeout != 0 {
+		viper.SetDefault("api-timeout", profile.ApiTimeout)
+	}
+	for key, value := range map[string]string{
+		"github-url":       profile.GithubUrl,
+		"gitlab-url":       profile.GitlabUrl,
+		"bitbucket-url":    profile.BitbucketUrl,
+		"azure-devops-url": profile.AzureDevopsUrl,
+	} {
+		if value != "" {
+			viper.SetDefault(key, value)
+		}
+	}
+	if profile.Output != "" {
+		for _, command := range []*cobra.Command{listCmd, getCmd} {
+			if flag := command.PersistentFlags().Lookup("output"); flag != nil && !flag.Changed {
+				cobra.CheckErr(flag.Value.Set(profile.Output))
+			}
+		}
+	}
+}
@@ -2,9 +2,12 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"time"
 
+	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
 
 	"github.com/creasty/defaults"
@@ -46,103 +49,259 @@ type DeployEvent struct {
 	Status       string    `json:"status,omitempty" yaml:"status"`
 }
 
+var deployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Report deploys to OpsLevel as they happen",
+	Long:  "Report deploys to OpsLevel as they happen",
+}
+
 var deployCreateCmd = &cobra.Command{
 	Use:   "deploy",
 	Short: "Create deployment events",
-	Long:  "Create deployment events (report a deployment to OpsLevel using an integration url)",
-	Run: func(cmd *cobra.Command, args []string) {
-		integrationUrl := viper.GetString("integration-url")
-		if integrationUrl == "" {
-			log.Error().Msg("Please provide '--integration-url' to send the deployment information to")
-			os.Exit(1)
-		}
+	Long: `Create deployment events (report a deployment to OpsLevel using an integration url)
 
-		evt, err := readCreateConfigAsDeployEvent()
-		cobra.CheckErr(err)
-		if dryrun := viper.GetBool("dry-run"); dryrun {
-			b, _ := json.Marshal(evt)
-			log.Info().Msgf("%s", string(b))
+When run in GitHub Actions, GitLab CI, CircleCI, Jenkins, Buildkite, Bitbucket Pipelines or Azure DevOps
+the deploy url, deploy number, deployer, branch, environment and dedup id are read from the
+standard variables of the CI provider. Flags and 'OPSLEVEL_*' environment variables take precedence.
+The dedup id of the CI provider is suffixed with the service and environment so that a job that
+deploys more than one service, like a matrix of deploys, sends one event per service.`,
+	PreRun: bindDeployEventFlags,
+	Example: `
+		opslevel create deploy -i $OPSLEVEL_INTEGRATION_URL -s my-service --environment production
+		opslevel create deploy -i $OPSLEVEL_INTEGRATION_URL --batch -f events.ndjson
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		integrationUrl := requireIntegrationUrl()
+		if viper.GetBool("batch") {
+			createDeployBatch(integrationUrl)
 			return
 		}
-		body, err := json.Marshal(evt)
-		cobra.CheckErr(err)
-		response := &opslevel.RestResponse{}
-		resp, err := getClientRest().R().
-			SetHeader("Content-Type", "application/json").
-			SetBody(body).
-			SetResult(response).
-			Post(integrationUrl)
+		evt, err := readCreateConfigAsDeployEvent()
 		cobra.CheckErr(err)
-		if !resp.IsSuccess() {
-			log.Error().Msg(resp.String())
+		if err := reportDeployEvent(integrationUrl, evt); err != nil {
+			log.Error().Msg(err.Error())
 			os.Exit(1)
 		}
-		log.Info().Msgf("Successfully registered deploy event for '%s'", evt.Service)
 	},
 }
 
 func init() {
+	rootCmd.AddCommand(deployCmd)
 	createCmd.AddCommand(deployCreateCmd)
+	addDeployEventFlags(deployCreateCmd)
+
+	deployCreateCmd.Flags().Bool("batch", false, "if true every event of the --file is sent, the file can hold NDJSON, a JSON list, YAML documents or a YAML list")
+}
 
-	deployCreateCmd.Flags().Bool("dry-run", false, "if true data will be logged and not sent to the integration-url (OPSLEVEL_DRY_RUN)")
+// addDeployEventFlags adds the flags used to build a DeployEvent to the command
+//
+// Commands using them must bind the flags with bindDeployEventFlags since the same keys are shared between commands.
+func addDeployEventFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("dry-run", false, "if true data will be logged and not sent to the integration-url (OPSLEVEL_DRY_RUN)")
 	viper.BindEnv("dry-run", "OPSLEVEL_DRY_RUN", "OL_DRY_RUN")
 
-	deployCreateCmd.Flags().String("commit-message", "", "git commit message associated with the event (OPSLEVEL_DEPLOYER_EMAIL)")
+	cmd.Flags().String("commit-message", "", "git commit message associated with the event (OPSLEVEL_DEPLOYER_EMAIL)")
 	viper.BindEnv("commit-message", "OPSLEVEL_COMMIT_MESSAGE", "OL_COMMIT_MESSAGE")
 
-	deployCreateCmd.Flags().String("commit-sha", "", "git commit sha associated with the event (OPSLEVEL_DEPLOYER_NAME)")
+	cmd.Flags().String("commit-sha", "", "git commit sha associated with the event (OPSLEVEL_DEPLOYER_NAME)")
 	viper.BindEnv("commit-sha", "OPSLEVEL_COMMIT_SHA", "OL_COMMIT_SHA")
 
-	deployCreateCmd.Flags().String("dedup-id", "", "dedup id of the event (OPSLEVEL_DEDUP_ID)")
+	cmd.Flags().String("dedup-id", "", "dedup id of the event (OPSLEVEL_DEDUP_ID)")
 	viper.BindEnv("dedup-id", "OPSLEVEL_DEDUP_ID", "OL_DEDUP_ID")
 
-	deployCreateCmd.Flags().String("deploy-number", "", "deploy number of the event (OPSLEVEL_DEPLOY_NUMBER)")
+	cmd.Flags().String("deploy-number", "", "deploy number of the event (OPSLEVEL_DEPLOY_NUMBER)")
 	viper.BindEnv("deploy-number", "OPSLEVEL_DEPLOY_NUMBER", "OL_DEPLOY_NUMBER")
 
-	deployCreateCmd.Flags().String("deploy-url", "", "url the event will link back to (OPSLEVEL_DEPLOY_URL)")
+	cmd.Flags().String("deploy-url", "", "url the event will link back to (OPSLEVEL_DEPLOY_URL)")
 	viper.BindEnv("deploy-url", "OPSLEVEL_DEPLOY_URL", "OL_DEPLOY_URL")
 
-	deployCreateCmd.Flags().String("deployer-email", "", "deployer email who created the event (OPSLEVEL_DEPLOYER_EMAIL)")
+	cmd.Flags().String("deployer-email", "", "deployer email who created the event (OPSLEVEL_DEPLOYER_EMAIL)")
 	viper.BindEnv("deployer-email", "OPSLEVEL_DEPLOYER_EMAIL", "OL_DEPLOYER_EMAIL")
 
-	deployCreateCmd.Flags().String("deployer-name", "", "deployer name who created the event (OPSLEVEL_DEPLOYER_NAME)")
+	cmd.Flags().String("deployer-name", "", "deployer name who created the event (OPSLEVEL_DEPLOYER_NAME)")
 	viper.BindEnv("deployer-name", "OPSLEVEL_DEPLOYER_NAME", "OL_DEPLOYER_NAME")
 
-	deployCreateCmd.Flags().String("environment", "", "environment name of the event (OPSLEVEL_ENVIRONMENT)")
+	cmd.Flags().String("environment", "", "environment name of the event (OPSLEVEL_ENVIRONMENT)")
 	viper.BindEnv("environment", "OPSLEVEL_ENVIRONMENT", "OL_ENVIRONMENT")
 
-	deployCreateCmd.Flags().String("git-path", "./", "relative path to grab the git commit info from (if git repo is found overrides all commit details)")
+	cmd.Flags().String("git-path", "./", "relative path to grab the git commit info from (if git repo is found overrides all commit details)")
 	viper.BindEnv("git-path", "OPSLEVEL_GIT_PATH", "OL_GIT_PATH")
 
-	deployCreateCmd.Flags().StringP("description", "d", "", "description of the event (OPSLEVEL_DESCRIPTION)")
+	cmd.Flags().StringP("description", "d", "", "description of the event (OPSLEVEL_DESCRIPTION)")
 	viper.BindEnv("description", "OPSLEVEL_DESCRIPTION", "OL_DESCRIPTION")
 
-	deployCreateCmd.Flags().String("status", "", "the status of the event, we accept any value but generally you should use one of ['queued','running','canceled','failure','success'] (OPSLEVEL_STATUS)")
+	cmd.Flags().String("status", "", "the status of the event, we accept any value but generally you should use one of ['queued','running','canceled','failure','success'] (OPSLEVEL_STATUS)")
 	viper.BindEnv("status", "OPSLEVEL_STATUS", "OL_STATUS")
 
-	deployCreateCmd.Flags().StringP("service", "s", "", "service alias for the event (OPSLEVEL_SERVICE)")
+	cmd.Flags().StringP("service", "s", "", "service alias for the event (OPSLEVEL_SERVICE)")
 	viper.BindEnv("service", "OPSLEVEL_SERVICE", "OL_SERVICE")
 
-	deployCreateCmd.Flags().StringP("integration-url", "i", "", "OpsLevel integration url (OPSLEVEL_INTEGRATION_URL)")
+	cmd.Flags().StringP("integration-url", "i", "", "OpsLevel integration url (OPSLEVEL_INTEGRATION_URL)")
 	viper.BindEnv("integration-url", "OPSLEVEL_INTEGRATION_URL", "OL_INTEGRATION_URL")
 
-	viper.BindPFlags(deployCreateCmd.Flags())
+	cmd.Flags().String("spool-dir", "", "directory to queue events that could not be delivered, replay them with 'opslevel deploy flush' (OPSLEVEL_SPOOL_DIR)")
+	viper.BindEnv("spool-dir", "OPSLEVEL_SPOOL_DIR", "OL_SPOOL_DIR")
+
+	cmd.Flags().Bool("fail-open", false, "if true a failure to report the event is logged as a warning and does not fail the command (OPSLEVEL_FAIL_OPEN)")
+	viper.BindEnv("fail-open", "OPSLEVEL_FAIL_OPEN", "OL_FAIL_OPEN")
+}
+
+func bindDeployEventFlags(cmd *cobra.Command, args []string) {
+	viper.BindPFlags(cmd.Flags())
+}
+
+func requireIntegrationUrl() string {
+	integrationUrl := viper.GetString("integration-url")
+	if integrationUrl == "" {
+		log.Error().Msg("Please provide '--integration-url' to send the deployment information to")
+		os.Exit(1)
+	}
+	return integrationUrl
+}
+
+// deployDeliveryError is a failure to reach the integration url that is worth retrying later
+type deployDeliveryError struct {
+	err error
+}
+
+func (e *deployDeliveryError) Error() string {
+	return e.err.Error()
+}
+
+func (e *deployDeliveryError) Unwrap() error {
+	return e.err
+}
+
+func isDeployDeliveryError(err error) bool {
+	var deliveryErr *deployDeliveryError
+	return errors.As(err, &deliveryErr)
+}
+
+// spooledDeployEvent is an event waiting in the --spool-dir to be replayed by 'opslevel deploy flush'
+type spooledDeployEvent struct {
+	IntegrationUrl string      `json:"integration_url"`
+	Event          DeployEvent `json:"event"`
+}
+
+// reportDeployEvent sends the event and handles a failure as set by --spool-dir and --fail-open
+func reportDeployEvent(integrationUrl string, evt *DeployEvent) error {
+	err := sendDeployEvent(integrationUrl, evt)
+	if err == nil {
+		if !viper.GetBool("dry-run") {
+			log.Info().Msgf("Successfully registered deploy event for '%s'", evt.Service)
+		}
+		return nil
+	}
+	return handleDeployFailure(integrationUrl, evt, err)
+}
+
+// handleDeployFailure returns nil when the failure to send the event is handled by --spool-dir or --fail-open
+//
+// Events that could not be delivered are queued in the --spool-dir, events that were rejected are not
+// since replaying them would fail the same way.
+func handleDeployFailure(integrationUrl string, evt *DeployEvent, err error) error {
+	if spoolDir := viper.GetString("spool-dir"); spoolDir != "" && isDeployDeliveryError(err) {
+		path, spoolErr := common.Spool{Dir: spoolDir}.Push(spooledDeployEvent{IntegrationUrl: integrationUrl, Event: *evt})
+		if spoolErr == nil {
+			log.Warn().Msgf("Failed to send deploy event for '%s', queued it as '%s': %s", evt.Service, path, err)
+			return nil
+		}
+		err = errors.Join(err, fmt.Errorf("failed to queue the deploy event: %w", spoolErr))
+	}
+	if viper.GetBool("fail-open") {
+		log.Warn().Msgf("Failed to send deploy event for '%s': %s", evt.Service, err)
+		return nil
+	}
+	return err
+}
+
+// sendDeployEvent posts the event to the integration url or only logs it when --dry-run is set
+func sendDeployEvent(integrationUrl string, evt *DeployEvent) error {
+	body, err := json.Marshal(evt)
+	if err != nil {
+		return err
+	}
+	if viper.GetBool("dry-run") {
+		log.Info().Msgf("%s", string(body))
+		return nil
+	}
+	response := &opslevel.RestResponse{}
+	resp, err := getClientRest().R().
+		SetHeader("Content-Type", "application/json").
+		SetBody(body).
+		SetResult(response).
+		Post(integrationUrl)
+	if err != nil {
+		return &deployDeliveryError{err: err}
+	}
+	if !resp.IsSuccess() {
+		err := fmt.Errorf("%s: %s", resp.Status(), resp.String())
+		if common.IsRetryableStatus(resp.StatusCode()) {
+			return &deployDeliveryError{err: err}
+		}
+		return err
+	}
+	return nil
 }
 
 func readCreateConfigAsDeployEvent() (*DeployEvent, error) {
 	readInputConfig()
+	return readDeployEvent()
+}
+
+// readDeployEvent builds the event from the config and CI provider, then applies the flags and the git repo on top
+func readDeployEvent() (*DeployEvent, error) {
 	evt := &DeployEvent{}
 	viper.Unmarshal(&evt)
+	fillWithCI(evt)
 	if err := defaults.Set(evt); err != nil {
 		return nil, err
 	}
 	evt.DeployedAt = time.Now().UTC()
 
 	fillWithOverrides(evt)
+	fillCIDedupID(evt)
 	fillGitInfo(evt)
 	return evt, nil
 }
 
+// fillWithCI fills the fields that are still empty from the environment of the detected CI provider
+func fillWithCI(evt *DeployEvent) {
+	ci, ok := common.DetectCI(os.Getenv)
+	if !ok {
+		return
+	}
+	log.Debug().Msgf("Detected CI provider '%s'", ci.Provider)
+	setIfEmpty(&evt.DeployURL, ci.DeployURL)
+	setIfEmpty(&evt.DeployNumber, ci.DeployNumber)
+	setIfEmpty(&evt.Deployer.Name, ci.DeployerName)
+	setIfEmpty(&evt.Deployer.Email, ci.DeployerEmail)
+	setIfEmpty(&evt.Environment, ci.Environment)
+	setIfEmpty(&evt.Commit.Branch, ci.Branch)
+	setIfEmpty(&evt.Commit.SHA, ci.CommitSHA)
+}
+
+// fillCIDedupID scopes the dedup id of the CI provider to the service and environment of the event
+// since a single CI job can deploy more than one service
+func fillCIDedupID(evt *DeployEvent) {
+	ci, ok := common.DetectCI(os.Getenv)
+	if !ok || ci.DedupID == "" || evt.DedupID != "" {
+		return
+	}
+	evt.DedupID = ci.DedupID
+	for _, scope := range []string{evt.Service, evt.Environment} {
+		if scope != "" {
+			evt.DedupID += "-" + scope
+		}
+	}
+}
+
+func setIfEmpty(field *string, value string) {
+	if *field == "" {
+		*field = value
+	}
+}
+
 func fillWithOverrides(evt *DeployEvent) {
 	if service := viper.GetString("service"); service != "" {
 		evt.Service = service
@@ -197,9 +356,15 @@ func fillGitInfo(evt *DeployEvent) {
 		log.Debug().Msg("Failed to read 'CommitObject' from hash of HEAD of git repo")
 		return
 	}
+	// CI checkouts are often a detached HEAD so only a branch checkout replaces the detected branch
+	branch := evt.Commit.Branch
+	if ref.Name().IsBranch() {
+		branch = ref.Name().Short()
+	}
 	evt.Commit = Commit{
 		SHA:            hash.String(),
 		Message:        commit.Message,
+		Branch:         branch,
 		Date:           &commit.Committer.When,
 		CommitterName:  commit.Committer.Name,
 		CommitterEmail: commit.Committer.Email,
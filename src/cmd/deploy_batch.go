@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/creasty/defaults"
+	"github.com/opslevel/cli/common"
+	"github.com/spf13/cobra"
+)
+
+// createDeployBatch sends every event of the --file with --concurrency requests in parallel
+//
+// Events are sent as written apart from the defaults of DeployEvent, the flags and CI provider
+// only apply to single events since a batch is usually a backfill of past deploys. Events that
+// fail to send are queued in the --spool-dir or ignored with --fail-open like a single event.
+func createDeployBatch(integrationUrl string) {
+	data, err := readInputData()
+	cobra.CheckErr(err)
+	format := common.DetectRecordFormat(dataFile, data)
+	if format == "csv" {
+		cobra.CheckErr(fmt.Errorf("--batch expects NDJSON, a JSON list, YAML documents or a YAML list"))
+	}
+	documents, err := common.ReadDocuments(data, format)
+	cobra.CheckErr(err)
+
+	events := make([]*DeployEvent, len(documents))
+	errs := make([]error, len(documents))
+	for i, document := range documents {
+		events[i], errs[i] = readBatchDeployEvent(document)
+	}
+
+	executor := newExecutor("Sending deploy events")
+	executor.Retryable = isDeployDeliveryError
+	sendErrs := executor.Run(len(events), func(i int) error {
+		if errs[i] != nil {
+			return errs[i]
+		}
+		return sendDeployEvent(integrationUrl, events[i])
+	})
+
+	var handled, failed int
+	for i, err := range sendErrs {
+		if err != nil && errs[i] == nil {
+			if err = handleDeployFailure(integrationUrl, events[i], err); err == nil {
+				handled++
+				fmt.Printf("line %d: not sent '%s'\n", documents[i].Line, events[i].Service)
+				continue
+			}
+		}
+		if err != nil {
+			failed++
+			fmt.Printf("line %d: failed: %s\n", documents[i].Line, strings.ReplaceAll(err.Error(), "\n", "; "))
+			continue
+		}
+		fmt.Printf("line %d: sent '%s'\n", documents[i].Line, events[i].Service)
+	}
+	fmt.Printf("%d sent, %d queued or ignored, %d failed\n", len(documents)-handled-failed, handled, failed)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func readBatchDeployEvent(document common.Document) (*DeployEvent, error) {
+	evt := &DeployEvent{}
+	if err := document.Decode(evt); err != nil {
+		return nil, err
+	}
+	if err := defaults.Set(evt); err != nil {
+		return nil, err
+	}
+	if err := common.ValidateRequired(evt); err != nil {
+		return nil, err
+	}
+	return evt, nil
+}
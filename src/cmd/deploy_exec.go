@@ -0,0 +1,130 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var deployExecCmd = &cobra.Command{
+	Use:   "exec [flags] -- COMMAND [ARGS...]",
+	Short: "Run a deploy command and report its progress",
+	Long: `Run a deploy command and report its progress
+
+A deploy event with the --start-status is sent before the command runs. Once it exits a 'success'
+or 'failure' event with the duration and exit code is sent. All events share the same dedup id
+so OpsLevel shows them as one deploy. The output of the command is streamed as it runs and
+the exit code of the command is returned.
+
+An event that fails to send is queued in the --spool-dir or ignored with --fail-open like
+'opslevel create deploy'. Otherwise the error is logged and the command still runs, a failed event
+never stops the deploy itself or changes the exit code unless --fail-on-report-error is set, then
+exec exits 1 when the command succeeded but an event could not be sent.`,
+	Example: `
+		opslevel deploy exec -i $OPSLEVEL_INTEGRATION_URL -s my-service -- ./deploy.sh production
+		opslevel deploy exec -i $OPSLEVEL_INTEGRATION_URL -s my-service --start-status queued -- helm upgrade my-service ./chart
+		opslevel deploy exec -i $OPSLEVEL_INTEGRATION_URL -s my-service --fail-on-report-error -- ./deploy.sh production
+		`,
+	Args:   cobra.MinimumNArgs(1),
+	PreRun: bindDeployEventFlags,
+	Run: func(cmd *cobra.Command, args []string) {
+		integrationUrl := requireIntegrationUrl()
+		startStatus := viper.GetString("start-status")
+		if !slices.Contains([]string{"queued", "running"}, startStatus) {
+			cobra.CheckErr(fmt.Errorf("--start-status must be one of: queued, running"))
+		}
+		failOnReportErr, err := cmd.Flags().GetBool("fail-on-report-error")
+		cobra.CheckErr(err)
+
+		evt, err := readDeployEvent()
+		cobra.CheckErr(err)
+		if evt.DedupID == "" {
+			evt.DedupID = uuid.NewString()
+		}
+		description := evt.Description
+
+		evt.Status = startStatus
+		reportErr := reportDeployEvent(integrationUrl, evt)
+		if reportErr != nil {
+			log.Error().Msg(reportErr.Error())
+		}
+
+		start := time.Now()
+		exitCode := runDeployCommand(args)
+		duration := time.Since(start).Round(time.Second)
+
+		evt.DeployedAt = time.Now().UTC()
+		evt.Status = "success"
+		if exitCode != 0 {
+			evt.Status = "failure"
+		}
+		evt.Description = fmt.Sprintf("%s (finished in %s with exit code %d)", description, duration, exitCode)
+		if err := reportDeployEvent(integrationUrl, evt); err != nil {
+			log.Error().Msg(err.Error())
+			reportErr = err
+		}
+		if failOnReportErr && reportErr != nil && exitCode == 0 {
+			exitCode = 1
+		}
+		os.Exit(exitCode)
+	},
+}
+
+func init() {
+	deployCmd.AddCommand(deployExecCmd)
+	addDeployEventFlags(deployExecCmd)
+
+	deployExecCmd.Flags().String("start-status", "running", "the status of the event sent before the command runs, one of ['queued','running'] (OPSLEVEL_START_STATUS)")
+	viper.BindEnv("start-status", "OPSLEVEL_START_STATUS", "OL_START_STATUS")
+	deployExecCmd.Flags().Bool("fail-on-report-error", false, "exit 1 when the command succeeded but an event could not be sent")
+	// Everything after the first argument belongs to the wrapped command even without '--'
+	deployExecCmd.Flags().SetInterspersed(false)
+}
+
+// runDeployCommand runs the command with the terminal of the CLI and returns its exit code
+//
+// The CLI ignores interrupts so the final event is still sent when the deploy is cancelled. Ctrl-C already
+// reaches the command through the terminal's process group, so only SIGTERM is forwarded to it.
+func runDeployCommand(args []string) int {
+	command := exec.Command(args[0], args[1:]...)
+	command.Stdin = os.Stdin
+	command.Stdout = os.Stdout
+	command.Stderr = os.Stderr
+	if err := command.Start(); err != nil {
+		log.Error().Msgf("failed to start '%s': %s", args[0], err)
+		return 127
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+	go func() {
+		for sig := range signals {
+			if sig == syscall.SIGTERM {
+				_ = command.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := command.Wait()
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &exitErr) && exitErr.ExitCode() > 0:
+		return exitErr.ExitCode()
+	default:
+		log.Error().Msgf("'%s' did not exit cleanly: %s", args[0], err)
+		return 1
+	}
+}
@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var deployFlushCmd = &cobra.Command{
+	Use:   "flush",
+	Short: "Replay the deploy events queued in the spool directory",
+	Long: `Replay the deploy events queued in the spool directory
+
+Events are sent oldest first with their original dedup id. An event that still can not be delivered
+is retried with exponential backoff, if it keeps failing the flush stops so the remaining events are
+replayed in order the next time. Events rejected by OpsLevel are renamed to '*.failed' and skipped.`,
+	Example: `
+		opslevel deploy flush --spool-dir /var/spool/opslevel
+		`,
+	Args:   cobra.NoArgs,
+	PreRun: bindDeployEventFlags,
+	Run: func(cmd *cobra.Command, args []string) {
+		spoolDir := viper.GetString("spool-dir")
+		if spoolDir == "" {
+			cobra.CheckErr(fmt.Errorf("please provide '--spool-dir' to replay the deploy events from"))
+		}
+		spool := common.Spool{Dir: spoolDir}
+		paths, err := spool.Entries()
+		cobra.CheckErr(err)
+
+		executor := &common.Executor{
+			Concurrency: 1,
+			Retries:     viper.GetInt("retries"),
+			Backoff:     time.Second,
+			Retryable:   isDeployDeliveryError,
+		}
+		var sent, rejected int
+		var failure error
+		for _, path := range paths {
+			var entry spooledDeployEvent
+			err := spool.Read(path, &entry)
+			if err == nil {
+				err = executor.Run(1, func(int) error {
+					return sendDeployEvent(entry.IntegrationUrl, &entry.Event)
+				})[0]
+			}
+			switch {
+			case err == nil:
+				sent++
+				failure = spool.Remove(path)
+			case isDeployDeliveryError(err):
+				failure = err
+			default:
+				failure = rejectDeployEvent(spool, path, err, &rejected)
+			}
+			if failure != nil {
+				break
+			}
+		}
+		fmt.Printf("%d sent, %d rejected, %d queued\n", sent, rejected, len(paths)-sent-rejected)
+		if failure != nil {
+			cobra.CheckErr(fmt.Errorf("stopped replaying the deploy events: %w", failure))
+		}
+	},
+}
+
+func init() {
+	deployCmd.AddCommand(deployFlushCmd)
+
+	deployFlushCmd.Flags().String("spool-dir", "", "directory the deploy events were queued in (OPSLEVEL_SPOOL_DIR)")
+	viper.BindEnv("spool-dir", "OPSLEVEL_SPOOL_DIR", "OL_SPOOL_DIR")
+	deployFlushCmd.Flags().Int("retries", 5, "number of times to retry an event that can not be delivered before stopping")
+}
+
+func rejectDeployEvent(spool common.Spool, path string, err error, rejected *int) error {
+	*rejected++
+	failedPath, renameErr := spool.Reject(path)
+	if renameErr != nil {
+		return renameErr
+	}
+	log.Error().Msgf("Deploy event '%s' was rejected and moved to '%s': %s", path, failedPath, err)
+	return nil
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var diffFiles []string
+
+// diffDriftExitCode is the exit status of 'opslevel diff' when drift is found, errors exit with 1
+const diffDriftExitCode = 2
+
+// diffUnverifiableExitCode is the exit status of 'opslevel diff' when there is no drift but some
+// existing resources can't be read back to compare them
+const diffUnverifiableExitCode = 3
+
+// manifestDrift counts the differences between manifests and the live resources
+//
+// Unverifiable counts existing resources that can't be read back, they are not drift.
+type manifestDrift struct {
+	Create       int
+	Add          int
+	Change       int
+	Delete       int
+	Unverifiable int
+}
+
+func (d *manifestDrift) Merge(other manifestDrift) {
+	d.Create += other.Create
+	d.Add += other.Add
+	d.Change += other.Change
+	d.Delete += other.Delete
+	d.Unverifiable += other.Unverifiable
+}
+
+func (d manifestDrift) HasDrift() bool {
+	return d.Create+d.Add+d.Change+d.Delete > 0
+}
+
+var diffCmd = &cobra.Command{
+	Use:   "diff",
+	Short: "Show the changes 'opslevel apply' would make",
+	Long: `Show the changes 'opslevel apply' would make without changing anything.
+
+Each manifest is compared to the live resource it matches. Only the fields declared in
+the manifest are compared, so fields managed outside of the manifest never show up as drift.
+Setting a field to null (or an empty string) in the manifest marks it for deletion.
+
+Existing secrets and trigger definitions can't be read back, they are counted as unverifiable
+instead of being compared.
+
+Exits with status 2 when any drift is found so it can be used to gate pull requests,
+status 3 when there is no drift but some resources are unverifiable, status 1 is kept
+for errors and status 0 means the live resources match the manifests.`,
+	Example: `
+		opslevel diff -f ./manifests/
+		opslevel diff -f ./manifests/ --no-color > plan.diff
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		noColor, err := cmd.Flags().GetBool("no-color")
+		cobra.CheckErr(err)
+		colored := !noColor && common.IsTerminal()
+		manifests, err := readManifests(diffFiles...)
+		cobra.CheckErr(err)
+
+		client := getClientGQL()
+		var total manifestDrift
+		for _, handler := range resourceHandlers {
+			var todo []*ResourceManifest
+			for i := range manifests {
+				if manifests[i].Kind == handler.kind {
+					todo = append(todo, &manifests[i])
+				}
+			}
+			if len(todo) == 0 {
+				continue
+			}
+			resources, err := handler.list(client)
+			cobra.CheckErr(err)
+			index := resourceIndex{}
+			specs := map[opslevel.ID]resourceSpec{}
+			for _, item := range resources {
+				index.add(item.Id, append(slices.Clone(item.Aliases), item.Name)...)
+				specs[item.Id] = item.Spec
+			}
+			for _, manifest := range todo {
+				diff, drift, err := diffManifest(handler.kind, index, specs, manifest)
+				cobra.CheckErr(err)
+				total.Merge(drift)
+				if diff == "" {
+					continue
+				}
+				if colored {
+					diff = common.ColorizeDiff(diff)
+				}
+				fmt.Println(diff)
+			}
+		}
+
+		fmt.Printf("Plan: %d to create, %d field(s) to add, %d to change, %d to delete, %d unverifiable.\n",
+			total.Create, total.Add, total.Change, total.Delete, total.Unverifiable)
+		switch {
+		case total.HasDrift():
+			os.Exit(diffDriftExitCode)
+		case total.Unverifiable > 0:
+			os.Exit(diffUnverifiableExitCode)
+		}
+	},
+}
+
+// diffManifest returns a unified diff between the listed resource and the manifest along with the drift counts
+func diffManifest(kind ResourceKind, index resourceIndex, specs map[opslevel.ID]resourceSpec, manifest *ResourceManifest) (string, manifestDrift, error) {
+	var drift manifestDrift
+	desired, err := decodeSpec[map[string]any](manifest)
+	if err != nil {
+		return "", drift, err
+	}
+	to, err := toYamlText(*desired)
+	if err != nil {
+		return "", drift, err
+	}
+
+	id, exists := index[manifest.Identifier()]
+	if !exists {
+		drift.Create++
+		return common.UnifiedDiff("/dev/null", manifest.Source, "", to), drift, nil
+	}
+
+	spec := specs[id]
+	if spec == nil {
+		log.Warn().Msgf("%s: %s '%s' exists but cannot be read back to compare it", manifest.Source, kind, manifest.Identifier())
+		drift.Unverifiable++
+		return "", drift, nil
+	}
+	live, err := toPlainValue(spec)
+	if err != nil {
+		return "", drift, err
+	}
+	live = pruneToManifest(live, *desired)
+	liveFields, _ := live.(map[string]any)
+	for key, want := range *desired {
+		have, ok := liveFields[key]
+		switch {
+		case isEmptyField(want):
+			if ok && !isEmptyField(have) {
+				drift.Delete++
+			}
+		case !ok:
+			drift.Add++
+		case !sameYaml(have, want):
+			drift.Change++
+		}
+	}
+
+	from, err := toYamlText(live)
+	if err != nil {
+		return "", drift, err
+	}
+	fromName := fmt.Sprintf("live/%s/%s", kind, manifest.Identifier())
+	return common.UnifiedDiff(fromName, manifest.Source, from, to), drift, nil
+}
+
+// pruneToManifest drops the parts of the live value that the manifest doesn't declare
+func pruneToManifest(live, desired any) any {
+	switch want := desired.(type) {
+	case map[string]any:
+		have, ok := live.(map[string]any)
+		if !ok {
+			return live
+		}
+		output := map[string]any{}
+		for key, value := range want {
+			if item, ok := have[key]; ok {
+				output[key] = pruneToManifest(item, value)
+			}
+		}
+		return output
+	case []any:
+		have, ok := live.([]any)
+		if !ok {
+			return live
+		}
+		output := make([]any, len(have))
+		for i := range have {
+			if i < len(want) {
+				output[i] = pruneToManifest(have[i], want[i])
+			} else {
+				output[i] = have[i]
+			}
+		}
+		return output
+	default:
+		// Identifiers can be written as a plain alias or id while the live resource has both
+		if have, ok := live.(map[string]any); ok {
+			if have["alias"] == desired || have["id"] == desired {
+				return desired
+			}
+			if alias, ok := have["alias"]; ok {
+				return alias
+			}
+		}
+		return live
+	}
+}
+
+func isEmptyField(value any) bool {
+	return value == nil || value == ""
+}
+
+// toPlainValue round trips a value through YAML so it can be compared with a decoded manifest
+func toPlainValue(value any) (any, error) {
+	data, err := yaml.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var output any
+	err = yaml.Unmarshal(data, &output)
+	return output, err
+}
+
+func toYamlText(value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+	data, err := yaml.Marshal(value)
+	return string(data), err
+}
+
+func sameYaml(a, b any) bool {
+	left, errA := toYamlText(a)
+	right, errB := toYamlText(b)
+	return errA == nil && errB == nil && left == right
+}
+
+func init() {
+	rootCmd.AddCommand(diffCmd)
+
+	diffCmd.Flags().StringArrayVarP(&diffFiles, "file", "f", []string{"-"}, "File or directory of YAML manifests to compare, can be repeated. Defaults to reading from stdin.")
+	diffCmd.Flags().Bool("no-color", false, "Disable colored output")
+}
@@ -1,11 +1,7 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
@@ -40,7 +36,7 @@ note: "Additional details"
 EOF
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		input, err := readResourceInput[opslevel.DomainInput]()
+		input, err := readOwnedResourceInput[opslevel.DomainInput]()
 		cobra.CheckErr(err)
 		result, err := getClientGQL().CreateDomain(*input)
 		cobra.CheckErr(err)
@@ -79,11 +75,7 @@ opslevel get domain my_domain
 		result, err := getClientGQL().GetDomain(key)
 		cobra.CheckErr(err)
 		common.WasFound(result.Id == "", key)
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -97,24 +89,13 @@ opslevel list domain
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListDomains(nil)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"NAME", "ID", "ALIASES"})
-			for _, item := range list {
-				w.Write([]string{item.Name, string(item.Id), strings.Join(item.Aliases, "/")})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Id, strings.Join(item.Aliases, ","))
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ID", "id"),
+			common.NewAliasesColumn("aliases"),
+			common.NewWideColumn("DESCRIPTION", "description"),
+		)
 	},
 }
 
@@ -134,7 +115,7 @@ EOF
 	ArgAliases: []string{"ID", "ALIAS"},
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		input, err := readResourceInput[opslevel.DomainInput]()
+		input, err := readOwnedResourceInput[opslevel.DomainInput]()
 		cobra.CheckErr(err)
 		domain, err := getClientGQL().UpdateDomain(key, *input)
 		cobra.CheckErr(err)
@@ -0,0 +1,215 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+)
+
+var createEventCmd = &cobra.Command{
+	Use:   "event",
+	Short: "Send a payload to a custom event integration",
+	Long: `Send a payload to a custom event integration
+
+The payload is posted to the webhook url of the integration where the custom event checks that
+use it are evaluated. The payload is read from --file or stdin, with --service and no payload
+'{"service": "ALIAS"}' is sent and otherwise the 'service' key is added to a payload without one.
+
+With --check-preview nothing is sent, instead the serviceSelector, successCondition and message of
+every custom event check using the integration are evaluated locally against the payload.
+Only the plain variables of the Liquid message are substituted, like '{{ data.path }}' and
+'{{ check.passed }}'. A message using Liquid tags or filters is printed as is with a warning.`,
+	Example: `
+		opslevel create event --integration custom-event-security-scan -f payload.json
+		cat payload.json | opslevel create event --integration custom-event-security-scan --check-preview
+		opslevel create event --integration custom-event-security-scan --service cart --check-preview
+		`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		key, err := cmd.Flags().GetString("integration")
+		cobra.CheckErr(err)
+		service, err := cmd.Flags().GetString("service")
+		cobra.CheckErr(err)
+		payload, err := readEventPayload(cmd.Flags().Changed("file"), service)
+		cobra.CheckErr(err)
+		integration, err := findEventIntegration(key)
+		cobra.CheckErr(err)
+
+		if preview, _ := cmd.Flags().GetBool("check-preview"); preview {
+			previewEventChecks(integration, payload, service)
+			return
+		}
+
+		url, err := getEventIntegrationWebhookUrl(integration.Id)
+		cobra.CheckErr(err)
+		body, err := json.Marshal(payload)
+		cobra.CheckErr(err)
+		if dryRun, _ := cmd.Flags().GetBool("dry-run"); dryRun {
+			log.Info().Msgf("POST %s %s", url, string(body))
+			return
+		}
+		resp, err := getClientRest().R().
+			SetHeader("Content-Type", "application/json").
+			SetBody(body).
+			Post(url)
+		cobra.CheckErr(err)
+		if !resp.IsSuccess() {
+			cobra.CheckErr(fmt.Errorf("%s: %s", resp.Status(), resp.String()))
+		}
+		log.Info().Msgf("Successfully sent the payload to integration '%s'", integration.Alias())
+	},
+}
+
+func init() {
+	createCmd.AddCommand(createEventCmd)
+
+	createEventCmd.Flags().String("integration", "", "ID, alias or name of the custom event integration to send the payload to")
+	createEventCmd.Flags().StringP("service", "s", "", "alias of the service the payload is about")
+	createEventCmd.Flags().Bool("check-preview", false, "if true the custom event checks of the integration are evaluated locally instead of sending the payload")
+	createEventCmd.Flags().Bool("dry-run", false, "if true the payload is logged and not sent to the integration")
+	createEventCmd.MarkFlagRequired("integration")
+}
+
+// readEventPayload reads the JSON payload from --file, stdin is only read when it is not a terminal so --service can be used alone
+func readEventPayload(fileChanged bool, service string) (any, error) {
+	var payload any
+	if fileChanged || !isStdInFromTerminal() {
+		data, err := readInputData()
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, fmt.Errorf("the payload must be JSON: %w", err)
+		}
+	}
+	if service == "" {
+		if payload == nil {
+			return nil, fmt.Errorf("please provide a payload with --file or stdin, or a --service")
+		}
+		return payload, nil
+	}
+	switch v := payload.(type) {
+	case nil:
+		return map[string]any{"service": service}, nil
+	case map[string]any:
+		if _, ok := v["service"]; !ok {
+			v["service"] = service
+		}
+	}
+	return payload, nil
+}
+
+func findEventIntegration(key string) (*opslevel.Integration, error) {
+	resp, err := getClientGQL().ListIntegrations(nil)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range resp.Nodes {
+		if string(item.Id) == key || item.Alias() == key || strings.EqualFold(item.Name, key) {
+			return &item, nil
+		}
+	}
+	return nil, fmt.Errorf("integration '%s' not found - run 'opslevel list integrations' to see the aliases", key)
+}
+
+func getEventIntegrationWebhookUrl(id opslevel.ID) (string, error) {
+	query := `query EventIntegrationWebhook($id: ID!) {
+  account {
+    integration(id: $id) {
+      ... on EventIntegration {
+        webhookUrl
+      }
+    }
+  }
+}`
+	data, err := getClientGQL().ExecRaw(query, map[string]any{"id": id})
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		Account struct {
+			Integration struct {
+				WebhookUrl string `json:"webhookUrl"`
+			} `json:"integration"`
+		} `json:"account"`
+	}
+	if err := json.Unmarshal(data, &response); err != nil {
+		return "", err
+	}
+	if response.Account.Integration.WebhookUrl == "" {
+		return "", fmt.Errorf("integration '%s' has no webhook url - is it a custom event integration?", id)
+	}
+	return response.Account.Integration.WebhookUrl, nil
+}
+
+// previewEventChecks evaluates the custom event checks of the integration like OpsLevel would when it receives the payload
+func previewEventChecks(integration *opslevel.Integration, payload any, service string) {
+	resp, err := getClientGQL().ListChecks(nil)
+	cobra.CheckErr(err)
+	var checks []opslevel.Check
+	for _, check := range resp.Nodes {
+		if check.Type == opslevel.CheckTypeGeneric && check.CustomEventCheckFragment.Integration.Id == integration.Id {
+			checks = append(checks, check)
+		}
+	}
+	if len(checks) == 0 {
+		fmt.Printf("no custom event checks use integration '%s'\n", integration.Alias())
+		return
+	}
+
+	var failed bool
+	for _, check := range checks {
+		fragment := check.CustomEventCheckFragment
+		fmt.Printf("%s\n", check.Name)
+
+		selected, err := common.EvaluateJQ(fragment.ServiceSelector, payload)
+		if err != nil {
+			failed = true
+			fmt.Printf("  service selector:  error: %s\n", err)
+		} else {
+			aliases := make([]string, len(selected))
+			for i, value := range selected {
+				aliases[i] = common.FormatJQValue(value)
+			}
+			match := ""
+			if service != "" {
+				match = fmt.Sprintf(" (does not select '%s')", service)
+				if slices.Contains(aliases, service) {
+					match = fmt.Sprintf(" (selects '%s')", service)
+				}
+			}
+			fmt.Printf("  service selector:  %s%s\n", strings.Join(aliases, ", "), match)
+		}
+
+		results, err := common.EvaluateJQ(fragment.SuccessCondition, payload)
+		passed := err == nil && len(results) > 0 && common.IsTruthy(results[0])
+		switch {
+		case err != nil:
+			failed = true
+			fmt.Printf("  success condition: error: %s\n", err)
+		case passed:
+			fmt.Println("  success condition: passed")
+		default:
+			failed = true
+			fmt.Println("  success condition: failed")
+		}
+
+		if fragment.ResultMessage != "" {
+			message, err := common.RenderMessage(fragment.ResultMessage, payload, passed)
+			if err != nil {
+				log.Warn().Err(err).Msg("unable to preview the message")
+			}
+			fmt.Printf("  message:\n    %s\n", strings.ReplaceAll(strings.TrimSpace(message), "\n", "\n    "))
+		}
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gosimple/slug"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/rs/zerolog/log"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// exportYamlKinds are the kinds written by 'export yaml', secrets and actions are skipped since they can't be read back safely
+var exportYamlKinds = []ResourceKind{
+	ResourceKindTeam,
+	ResourceKindPropertyDefinition,
+	ResourceKindDomain,
+	ResourceKindSystem,
+	ResourceKindService,
+	ResourceKindInfra,
+	ResourceKindFilter,
+	ResourceKindCategory,
+	ResourceKindLevel,
+	ResourceKindScorecard,
+	ResourceKindCheck,
+}
+
+var exportYamlCmd = &cobra.Command{
+	Use:   "yaml [Directory]",
+	Short: "Exports your account data as YAML files for 'opslevel create' or 'opslevel apply'",
+	Long: `Writes one YAML file per resource to disk, grouped in a directory per kind.
+
+Each file is the input that the matching 'opslevel create <kind> -f' command accepts, checks use
+the format of 'opslevel create check -f'. Owners, filters and the categories, levels and integrations
+of checks are written by alias so the files can be created in another account, a reference that
+has no alias is kept as an ID with a warning.
+
+With --manifests each file is wrapped in the manifest format that 'opslevel apply' and
+'opslevel diff' read instead, so the export can be used as the starting point for managing
+your account from git.`,
+	Example: `
+		opslevel export yaml ./opslevel
+		opslevel create team -f ./opslevel/team/platform.yaml
+
+		opslevel export yaml ./opslevel --manifests
+		opslevel diff -f ./opslevel
+		`,
+	Args:       cobra.MaximumNArgs(1),
+	ArgAliases: []string{"Directory"},
+	Run:        runExportYaml,
+}
+
+func init() {
+	exportCmd.AddCommand(exportYamlCmd)
+
+	exportYamlCmd.Flags().Bool("manifests", false, "Write manifests for 'opslevel apply' instead of the input of 'opslevel create'")
+}
+
+func runExportYaml(cmd *cobra.Command, args []string) {
+	manifests, err := cmd.Flags().GetBool("manifests")
+	cobra.CheckErr(err)
+	var path string
+	if len(args) > 0 {
+		path = args[0]
+	} else {
+		path = "opslevel"
+	}
+	directory, err := filepath.Abs(path)
+	cobra.CheckErr(err)
+	cobra.CheckErr(os.MkdirAll(directory, os.ModePerm))
+	fmt.Printf("Writing files to: %s\n", directory)
+
+	client := getClientGQL()
+	listed := make([][]listedResource, len(exportYamlKinds))
+	errs := newExecutor("export").Run(len(exportYamlKinds), func(i int) error {
+		handler, _ := getResourceHandler(exportYamlKinds[i])
+		resources, err := handler.list(client)
+		listed[i] = resources
+		return err
+	})
+	cobra.CheckErr(errors.Join(errs...))
+	aliases := map[string]string{}
+	for _, resources := range listed {
+		for _, resource := range resources {
+			if len(resource.Aliases) > 0 {
+				aliases[string(resource.Id)] = resource.Aliases[0]
+			}
+		}
+	}
+	for i, kind := range exportYamlKinds {
+		resources := listed[i]
+		kindDirectory := filepath.Join(directory, string(kind))
+		cobra.CheckErr(os.MkdirAll(kindDirectory, os.ModePerm))
+		filenames := map[string]int{}
+		for _, resource := range resources {
+			manifest, err := toManifest(kind, resource, aliases)
+			cobra.CheckErr(err)
+			var document any = manifest
+			if !manifests {
+				document, err = toCreateInput(manifest)
+				cobra.CheckErr(err)
+			}
+			data, err := yaml.Marshal(document)
+			cobra.CheckErr(err)
+			filename := uniqueFilename(filenames, manifest.Identifier())
+			cobra.CheckErr(os.WriteFile(filepath.Join(kindDirectory, filename), data, 0o644))
+		}
+		fmt.Printf("Exported %d %s resource(s)\n", len(resources), kind)
+	}
+	fmt.Println("Complete!")
+}
+
+// toManifest converts an existing resource to a manifest that 'opslevel apply' will match back to it
+//
+// aliases maps the IDs of the exported resources to their alias to replace the references of checks.
+func toManifest(kind ResourceKind, resource listedResource, aliases map[string]string) (*ResourceManifest, error) {
+	manifest := &ResourceManifest{
+		Version: ManifestCurrentVersion,
+		Kind:    kind,
+	}
+	if len(resource.Aliases) > 0 && kind != ResourceKindCheck {
+		manifest.Alias = resource.Aliases[0]
+	}
+	spec := preferAliases(resource.Spec).(resourceSpec)
+	for _, reference := range []string{"owner", "filter"} {
+		if item, ok := spec[reference].(resourceSpec); ok && item["alias"] != nil {
+			delete(spec, reference+"Id")
+		}
+	}
+	if kind == ResourceKindCheck {
+		aliasCheckReferences(spec, aliases)
+	}
+	if err := manifest.Spec.Encode(spec); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// toCreateInput returns the spec of the manifest as the input of its 'opslevel create' command
+func toCreateInput(manifest *ResourceManifest) (any, error) {
+	if manifest.Kind == ResourceKindCheck {
+		return decodeCheckSpec(manifest)
+	}
+	spec, err := decodeSpec[map[string]any](manifest)
+	if err != nil {
+		return nil, err
+	}
+	return *spec, nil
+}
+
+// preferAliases drops the ID of references that also have an alias so the manifest can be applied to other accounts
+func preferAliases(value any) any {
+	switch v := value.(type) {
+	case resourceSpec:
+		output := resourceSpec{}
+		for key, item := range v {
+			output[key] = preferAliases(item)
+		}
+		if _, ok := output["alias"]; ok {
+			delete(output, "id")
+		}
+		return output
+	case []resourceSpec:
+		output := make([]any, len(v))
+		for i, item := range v {
+			output[i] = preferAliases(item)
+		}
+		return output
+	default:
+		return value
+	}
+}
+
+// checkReferences are the fields of a check spec that reference another resource
+var checkReferences = []string{"category", "level", "filter", "owner", "integration"}
+
+// aliasCheckReferences replaces the references of a check that are written as an ID with the alias of the resource
+func aliasCheckReferences(spec resourceSpec, aliases map[string]string) {
+	for _, key := range checkReferences {
+		value, ok := spec[key].(string)
+		if !ok || !opslevel.IsID(value) {
+			continue
+		}
+		if alias, ok := aliases[value]; ok {
+			spec[key] = alias
+			continue
+		}
+		log.Warn().Msgf("check '%s' references its %s by the ID '%s' which only exists in this account", spec["name"], key, value)
+	}
+}
+
+func uniqueFilename(used map[string]int, identifier string) string {
+	name := slug.Make(identifier)
+	if name == "" {
+		name = "resource"
+	}
+	used[name]++
+	if count := used[name]; count > 1 {
+		name = fmt.Sprintf("%s-%d", name, count)
+	}
+	return name + ".yaml"
+}
@@ -65,7 +65,7 @@ var getFilterCmd = &cobra.Command{
 		key := args[0]
 		filter, err := getClientGQL().GetFilter(opslevel.ID(key))
 		cobra.CheckErr(err)
-		common.PrettyPrint(filter)
+		printResult(filter)
 	},
 }
 
@@ -77,16 +77,11 @@ var listFilterCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListFilters(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Alias(), item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			columnOf("ALIAS", func(item opslevel.Filter) string { return item.Alias() }),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
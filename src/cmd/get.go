@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"github.com/opslevel/cli/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -16,10 +17,15 @@ var getCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(getCmd)
 
-	getCmd.PersistentFlags().StringVarP(&getOutputType, "output", "o", "text", "Output format.  One of: yaml|text [default: text]")
+	getCmd.PersistentFlags().StringVarP(&getOutputType, "output", "o", "text", "Output format.  One of: "+common.OutputFormats+" [default: text]")
 	viper.BindPFlags(getCmd.Flags())
 }
 
 func isYamlOutput() bool {
 	return getOutputType == "yaml"
 }
+
+// printResult writes the result of a get command in the format selected with --output
+func printResult(result any, columns ...common.Column) {
+	cobra.CheckErr(common.NewPrinter(getOutputType, columns...).PrintItem(result))
+}
@@ -1,32 +1,160 @@
 package cmd
 
 import (
+	"cmp"
+	"encoding/csv"
 	"fmt"
+	"os"
+	"slices"
 
 	"github.com/opslevel/cli/common"
+	"github.com/rs/zerolog/log"
 	"github.com/spf13/cobra"
 )
 
-var importFilepath string
+var (
+	importFilepath       string
+	importFormat         string
+	importFailedRowsPath string
+)
 
 var importCmd = &cobra.Command{
 	Use:   "import",
 	Short: "Import data to OpsLevel.",
-	Long:  "Import data to OpsLevel.",
+	Long: `Import data to OpsLevel.
+
+Every import prints how many rows were created, updated, skipped and failed and exits 1 when any row failed.`,
 }
 
 func init() {
 	rootCmd.AddCommand(importCmd)
 
 	importCmd.PersistentFlags().StringVarP(&importFilepath, "filepath", "f", "-", "File to read data from. Defaults to reading from stdin.")
+	importCmd.PersistentFlags().StringVar(&importFormat, "format", "", "Format of the file. One of: "+common.RecordFormats+" [default: detected from the file extension or content]")
+	importCmd.PersistentFlags().StringVar(&importFailedRowsPath, "failed-rows", "", "Write the rows that failed to this CSV file with an extra 'error' column so they can be fixed and imported again")
 }
 
-func readImportFilepathAsCSV() (*common.CSVReader, error) {
+// readImportFilepath reads the --filepath file as CSV, JSON, NDJSON or YAML into the same rows
+func readImportFilepath() (common.RecordReader, error) {
 	if importFilepath == "" {
 		return nil, fmt.Errorf("empty filepath specified")
 	}
-	if importFilepath == "-" {
-		return common.ReadCSVFile("/dev/stdin")
+	return common.ReadRecordsFile(importFilepath, importFormat)
+}
+
+// readImportRecords reads every row of --filepath that isn't blank, blank rows are counted as skipped
+func readImportRecords() ([]common.Record, *importReport) {
+	reader, err := readImportFilepath()
+	cobra.CheckErr(err)
+	defer reader.Close()
+	report := &importReport{headers: reader.HeaderNames()}
+	var records []common.Record
+	for _, record := range common.ReadAllRecords(reader) {
+		if record.IsBlank() {
+			report.Skipped++
+			continue
+		}
+		records = append(records, record)
+	}
+	return records, report
+}
+
+type importOutcome int
+
+const (
+	importCreated importOutcome = iota
+	importUpdated
+)
+
+// runImport runs the task for every record with --concurrency workers and adds the outcome of each to the report
+func runImport(report *importReport, label string, records []common.Record, task func(record common.Record) (importOutcome, error)) {
+	outcomes := make([]importOutcome, len(records))
+	errs := newExecutor(label).Run(len(records), func(i int) error {
+		outcome, err := task(records[i])
+		outcomes[i] = outcome
+		return err
+	})
+	for i, err := range errs {
+		switch {
+		case err != nil:
+			log.Error().Err(err).Msgf("failed to import row %d", records[i].Line)
+			report.Fail(records[i], err)
+		case outcomes[i] == importUpdated:
+			report.Updated++
+		default:
+			report.Created++
+		}
+	}
+}
+
+// importReport counts what happened to each row of an import and keeps the rows that failed
+//
+// With DryRun set Created and Updated count the rows that would be created or updated.
+type importReport struct {
+	Created    int
+	Updated    int
+	Skipped    int
+	Failed     int
+	DryRun     bool
+	headers    []string
+	failedRows []importFailedRow
+}
+
+type importFailedRow struct {
+	line   int
+	values []string
+}
+
+// Fail records the row along with the reason it failed
+func (report *importReport) Fail(record common.Record, err error) {
+	report.Failed++
+	report.failedRows = append(report.failedRows, importFailedRow{
+		line:   record.Line,
+		values: append(slices.Clone(record.Values()), err.Error()),
+	})
+}
+
+// Finish prints the summary, writes the failed rows to --failed-rows when it is set and exits 1 if any row failed
+func (report *importReport) Finish() {
+	if report.DryRun {
+		fmt.Printf("%d would create, %d would update, %d skipped, %d failed\n", report.Created, report.Updated, report.Skipped, report.Failed)
+	} else {
+		fmt.Printf("%d created, %d updated, %d skipped, %d failed\n", report.Created, report.Updated, report.Skipped, report.Failed)
+	}
+	if importFailedRowsPath != "" {
+		cobra.CheckErr(report.writeFailedRows(importFailedRowsPath))
+		if report.Failed > 0 {
+			fmt.Printf("wrote %d failed row(s) to '%s'\n", report.Failed, importFailedRowsPath)
+		}
+	}
+	if report.Failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// writeFailedRows writes the failed rows in the order of the file since concurrent imports fail in any order
+func (report *importReport) writeFailedRows(path string) (err error) {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := file.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+	slices.SortStableFunc(report.failedRows, func(a, b importFailedRow) int {
+		return cmp.Compare(a.line, b.line)
+	})
+	writer := csv.NewWriter(file)
+	if err := writer.Write(append(slices.Clone(report.headers), "error")); err != nil {
+		return err
+	}
+	for _, row := range report.failedRows {
+		if err := writer.Write(row.values); err != nil {
+			return err
+		}
 	}
-	return common.ReadCSVFile(importFilepath)
+	writer.Flush()
+	return writer.Error()
 }
@@ -1,12 +1,9 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
-	"strings"
 
 	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
@@ -95,11 +92,7 @@ opslevel --log-level=ERROR get infra-schema Compute > ~/.opslevel/schemas/comput
 		for k, v := range schema.Schema {
 			dto[k] = v
 		}
-		if isYamlOutput() {
-			common.YamlPrint(dto)
-		} else {
-			common.PrettyPrint(dto)
-		}
+		printResult(dto)
 	},
 }
 
@@ -115,11 +108,7 @@ var getInfraCmd = &cobra.Command{
 		result, err := getClientGQL().GetInfrastructure(key)
 		cobra.CheckErr(err)
 		common.WasFound(result.Id == "", key)
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -140,24 +129,8 @@ do
 done`,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListInfrastructureSchemas(nil)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"TYPE"})
-			for _, item := range list {
-				w.Write([]string{item.Type})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("TYPE")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t\n", item.Type)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes, common.NewColumn("TYPE", "type"))
 	},
 }
 
@@ -180,24 +153,12 @@ opslevel list infra -o json | jq 'map(select(.type == "Database") | .data | from
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListInfrastructure(nil)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"NAME", "ID", "ALIASES"})
-			for _, item := range list {
-				w.Write([]string{item.Name, string(item.Id), strings.Join(item.Aliases, "/")})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("NAME", "ID", "ALIASES")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Id, strings.Join(item.Aliases, ","))
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ID", "id"),
+			common.NewAliasesColumn("aliases"),
+		)
 	},
 }
 
@@ -2,8 +2,10 @@ package cmd
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 
+	"github.com/opslevel/opslevel-go/v2025"
 	"github.com/rs/zerolog/log"
 
 	"github.com/spf13/viper"
@@ -31,34 +33,122 @@ func readInputConfig() {
 }
 
 func readResourceInput[T any]() (*T, error) {
-	var err error
 	var resource T
-	var yamlData []byte
+	yamlData, err := readInputData()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := yaml.Unmarshal(yamlData, &resource); err != nil {
+		return nil, err
+	}
+	return &resource, nil
+}
 
+// readInputData reads the raw contents of the --file
+func readInputData() ([]byte, error) {
 	switch dataFile {
 	case ".":
-		yamlData, err = os.ReadFile("./data.yaml")
+		return os.ReadFile("./data.yaml")
 	case "-":
 		if isStdInFromTerminal() {
 			log.Info().Msg("Reading input directly from command line... Press CTRL+D to stop typing")
 		}
 		buf := bytes.Buffer{}
-		_, err = buf.ReadFrom(os.Stdin)
-		yamlData = buf.Bytes()
+		_, err := buf.ReadFrom(os.Stdin)
+		return buf.Bytes(), err
 	default:
-		yamlData, err = os.ReadFile(dataFile)
+		return os.ReadFile(dataFile)
 	}
+}
+
+func isStdInFromTerminal() bool {
+	fi, _ := os.Stdin.Stat()
+	return fi.Mode()&os.ModeCharDevice != 0
+}
+
+// readOwnedResourceInput reads the --file like readResourceInput but also accepts the 'owner' and
+// 'filter' references written by 'opslevel export yaml' in place of 'ownerId' and 'filterId'
+func readOwnedResourceInput[T any]() (*T, error) {
+	yamlData, err := readInputData()
 	if err != nil {
 		return nil, err
 	}
+	var spec map[string]any
+	if err := yaml.Unmarshal(yamlData, &spec); err != nil {
+		return nil, err
+	}
+	return decodeOwnedInput[T](getClientGQL(), spec)
+}
 
-	if err := yaml.Unmarshal(yamlData, &resource); err != nil {
+// decodeOwnedInput resolves the 'owner' team and 'filter' references of the spec to their IDs then decodes it
+func decodeOwnedInput[T any](client *opslevel.Client, spec map[string]any) (*T, error) {
+	if owner, ok := spec["owner"]; ok {
+		if _, ok := spec["ownerId"]; !ok {
+			id, err := resolveReference("owner", owner, func(alias string) (opslevel.ID, error) {
+				team, err := client.GetTeamWithAlias(alias)
+				if err != nil {
+					return "", err
+				}
+				return team.Id, nil
+			})
+			if err != nil {
+				return nil, err
+			}
+			spec["ownerId"] = string(id)
+		}
+		delete(spec, "owner")
+	}
+	if filter, ok := spec["filter"]; ok {
+		if _, ok := spec["filterId"]; !ok {
+			id, err := resolveReference("filter", filter, func(alias string) (opslevel.ID, error) {
+				filters, err := client.ListFilters(nil)
+				if err != nil {
+					return "", err
+				}
+				for _, item := range filters.Nodes {
+					if item.Alias() == alias {
+						return item.Id, nil
+					}
+				}
+				return "", fmt.Errorf("filter with alias '%s' not found", alias)
+			})
+			if err != nil {
+				return nil, err
+			}
+			spec["filterId"] = string(id)
+		}
+		delete(spec, "filter")
+	}
+
+	data, err := yaml.Marshal(spec)
+	if err != nil {
+		return nil, err
+	}
+	var resource T
+	if err := yaml.Unmarshal(data, &resource); err != nil {
 		return nil, err
 	}
 	return &resource, nil
 }
 
-func isStdInFromTerminal() bool {
-	fi, _ := os.Stdin.Stat()
-	return fi.Mode()&os.ModeCharDevice != 0
+// resolveReference returns the ID of a reference written as '{id: ..., alias: ...}' or as a plain alias
+func resolveReference(field string, reference any, byAlias func(alias string) (opslevel.ID, error)) (opslevel.ID, error) {
+	var alias string
+	switch value := reference.(type) {
+	case string:
+		alias = value
+	case map[string]any:
+		if id, ok := value["id"].(string); ok && id != "" {
+			return opslevel.ID(id), nil
+		}
+		alias, _ = value["alias"].(string)
+	}
+	if alias == "" {
+		return "", fmt.Errorf("'%s' must have an 'id' or an 'alias'", field)
+	}
+	if opslevel.IsID(alias) {
+		return opslevel.ID(alias), nil
+	}
+	return byAlias(alias)
 }
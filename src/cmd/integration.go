@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 	"slices"
 
@@ -173,7 +172,7 @@ var getIntegrationCmd = &cobra.Command{
 		key := args[0]
 		integration, err := getClientGQL().GetIntegration(opslevel.ID(key))
 		cobra.CheckErr(err)
-		common.PrettyPrint(integration)
+		printResult(integration)
 	},
 }
 
@@ -185,16 +184,12 @@ var listIntegrationCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListIntegrations(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "TYPE", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", item.Name, item.Type, item.Alias(), item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("TYPE", "type"),
+			columnOf("ALIAS", func(item opslevel.Integration) string { return item.Alias() }),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
@@ -1,11 +1,17 @@
 package cmd
 
 import (
+	"github.com/opslevel/cli/common"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
-var listOutputType string
+var (
+	listOutputType string
+	listColumns    []string
+	listSortBy     string
+	listWide       bool
+)
 
 var listCmd = &cobra.Command{
 	Use:     "list",
@@ -17,14 +23,28 @@ var listCmd = &cobra.Command{
 func init() {
 	rootCmd.AddCommand(listCmd)
 
-	listCmd.PersistentFlags().StringVarP(&listOutputType, "output", "o", "text", "Output format.  One of: json|csv|text [default: text]")
+	listCmd.PersistentFlags().StringVarP(&listOutputType, "output", "o", "text", "Output format.  One of: "+common.OutputFormats+" [default: text]")
+	listCmd.PersistentFlags().StringSliceVar(&listColumns, "columns", nil, "Comma separated columns to show in text, csv and tsv output. Each is a column name or a field path like 'owner.alias', optionally as HEADER:PATH")
+	listCmd.PersistentFlags().StringVar(&listSortBy, "sort-by", "", "Sort the list by a column name or a field path like 'owner.alias'")
+	listCmd.PersistentFlags().BoolVar(&listWide, "wide", false, "Show additional columns in text, csv and tsv output")
 	viper.BindPFlags(listCmd.Flags())
 }
 
-func isJsonOutput() bool {
-	return listOutputType == "json"
+// printList writes the result of a list command in the format selected with --output
+func printList(items any, columns ...common.Column) {
+	printer := common.NewPrinter(listOutputType, columns...)
+	printer.Select = listColumns
+	printer.SortBy = listSortBy
+	printer.Wide = listWide
+	cobra.CheckErr(printer.PrintList(items))
 }
 
-func isCsvOutput() bool {
-	return listOutputType == "csv"
+// columnOf is a column whose value is computed from the typed list item
+func columnOf[T any](header string, value func(item T) string) common.Column {
+	return common.Column{
+		Header: header,
+		Value: func(item any) string {
+			return value(item.(T))
+		},
+	}
 }
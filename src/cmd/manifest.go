@@ -0,0 +1,901 @@
+package cmd
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/opslevel/opslevel-go/v2025"
+	"gopkg.in/yaml.v3"
+)
+
+var ManifestCurrentVersion = "1"
+
+// ResourceKind is the 'kind' of a document in a manifest file
+type ResourceKind string
+
+const (
+	ResourceKindAction             ResourceKind = "action"
+	ResourceKindCategory           ResourceKind = "category"
+	ResourceKindCheck              ResourceKind = "check"
+	ResourceKindDomain             ResourceKind = "domain"
+	ResourceKindFilter             ResourceKind = "filter"
+	ResourceKindInfra              ResourceKind = "infra"
+	ResourceKindLevel              ResourceKind = "level"
+	ResourceKindPropertyDefinition ResourceKind = "property-definition"
+	ResourceKindScorecard          ResourceKind = "scorecard"
+	ResourceKindSecret             ResourceKind = "secret"
+	ResourceKindService            ResourceKind = "service"
+	ResourceKindSystem             ResourceKind = "system"
+	ResourceKindTeam               ResourceKind = "team"
+	ResourceKindTriggerDefinition  ResourceKind = "trigger-definition"
+)
+
+// ResourceManifest is a single document of a manifest file
+//
+//	version: "1"
+//	kind: team
+//	alias: platform
+//	spec:
+//	  name: Platform
+//
+// Checks can also be written in the same format that 'opslevel create check' accepts
+// where 'kind' is the check type, those are converted to a 'check' manifest whose
+// spec has an extra 'type' field.
+type ResourceManifest struct {
+	Version string       `yaml:"version"`
+	Kind    ResourceKind `yaml:"kind"`
+	Alias   string       `yaml:"alias,omitempty"`
+	Spec    yaml.Node    `yaml:"spec"`
+	Source  string       `yaml:"-"`
+}
+
+// Name returns the 'name' field of the manifest spec, infra resources keep their name in 'data'
+func (m *ResourceManifest) Name() string {
+	var spec struct {
+		Name string `yaml:"name"`
+		Data struct {
+			Name string `yaml:"name"`
+		} `yaml:"data"`
+	}
+	_ = m.Spec.Decode(&spec)
+	if spec.Name == "" {
+		return spec.Data.Name
+	}
+	return spec.Name
+}
+
+// Identifier returns the key used to match the manifest to an existing resource
+func (m *ResourceManifest) Identifier() string {
+	if m.Alias != "" {
+		return m.Alias
+	}
+	return m.Name()
+}
+
+func decodeSpec[T any](m *ResourceManifest) (*T, error) {
+	var output T
+	if err := m.Spec.Decode(&output); err != nil {
+		return nil, fmt.Errorf("%s: unable to read spec of %s '%s': %w", m.Source, m.Kind, m.Identifier(), err)
+	}
+	return &output, nil
+}
+
+// decodeOwnedSpec decodes the spec of resources that may reference their owner and filter by alias
+func decodeOwnedSpec[T any](client *opslevel.Client, m *ResourceManifest) (*T, error) {
+	spec, err := decodeSpec[map[string]any](m)
+	if err != nil {
+		return nil, err
+	}
+	output, err := decodeOwnedInput[T](client, *spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: unable to read spec of %s '%s': %w", m.Source, m.Kind, m.Identifier(), err)
+	}
+	return output, nil
+}
+
+// listedResource is an existing resource as returned by the list function of a resourceHandler
+type listedResource struct {
+	Id      opslevel.ID
+	Aliases []string
+	Name    string
+	Spec    resourceSpec // nil if the resource can't be read back
+}
+
+// resourceIndex maps the aliases and names of existing resources to their ID
+type resourceIndex map[string]opslevel.ID
+
+func (index resourceIndex) add(id opslevel.ID, keys ...string) {
+	for _, key := range keys {
+		if key != "" {
+			index[key] = id
+		}
+	}
+}
+
+// resourceHandler knows how to find, read, create and update a single kind of resource
+type resourceHandler struct {
+	kind   ResourceKind
+	list   func(client *opslevel.Client) ([]listedResource, error)
+	create func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error)
+	update func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error
+}
+
+// resourceHandlers is ordered such that resources are created before the resources that reference them
+var resourceHandlers = []resourceHandler{
+	{
+		kind: ResourceKindTeam,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListTeams(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeTeam(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.TeamCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateTeam(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.TeamUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = &id
+			_, err = client.UpdateTeam(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindPropertyDefinition,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListPropertyDefinitions(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizePropertyDefinition(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodePropertyDefinitionSpec(m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreatePropertyDefinition(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodePropertyDefinitionSpec(m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdatePropertyDefinition(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindDomain,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListDomains(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeDomain(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeOwnedSpec[opslevel.DomainInput](client, m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateDomain(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeOwnedSpec[opslevel.DomainInput](client, m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateDomain(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindSystem,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListSystems(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeSystem(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeOwnedSpec[opslevel.SystemInput](client, m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateSystem(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeOwnedSpec[opslevel.SystemInput](client, m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateSystem(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindService,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListServices(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeService(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.ServiceCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateService(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.ServiceUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = &id
+			_, err = client.UpdateService(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindInfra,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListInfrastructure(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeInfra(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.InfraInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateInfrastructure(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.InfraInput](m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateInfrastructure(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindSecret,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListSecretsVaultsSecret(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: []string{item.Alias},
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			if m.Alias == "" {
+				return "", fmt.Errorf("%s: secrets require the 'alias' field to be set", m.Source)
+			}
+			input, err := decodeSpec[opslevel.SecretInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateSecret(m.Alias, *input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.SecretInput](m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateSecret(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindFilter,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListFilters(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: []string{item.Alias()},
+					Name:    item.Name,
+					Spec:    normalizeFilter(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.FilterCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateFilter(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.FilterUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = id
+			_, err = client.UpdateFilter(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindCategory,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListCategories(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: []string{item.Alias()},
+					Name:    item.Name,
+					Spec:    normalizeCategory(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.CategoryCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateCategory(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.CategoryUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = id
+			_, err = client.UpdateCategory(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindLevel,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListLevels(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: []string{item.Alias},
+					Name:    item.Name,
+					Spec:    normalizeLevel(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.LevelCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateLevel(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.LevelUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = id
+			_, err = client.UpdateLevel(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindScorecard,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListScorecards(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:      item.Id,
+					Aliases: item.Aliases,
+					Name:    item.Name,
+					Spec:    normalizeScorecard(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeOwnedSpec[opslevel.ScorecardInput](client, m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateScorecard(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, ensureAlias(client, result.Id, result.Aliases, m.Alias)
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeOwnedSpec[opslevel.ScorecardInput](client, m)
+			if err != nil {
+				return err
+			}
+			_, err = client.UpdateScorecard(string(id), *input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindAction,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListCustomActions(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:   item.CustomActionsId.Id,
+					Name: item.Name,
+					Spec: normalizeAction(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.CustomActionsWebhookActionCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateWebhookAction(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.CustomActionsId.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.CustomActionsWebhookActionUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = id
+			_, err = client.UpdateWebhookAction(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindTriggerDefinition,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListTriggerDefinitions(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:   item.Id,
+					Name: item.Name,
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeSpec[opslevel.CustomActionsTriggerDefinitionCreateInput](m)
+			if err != nil {
+				return "", err
+			}
+			result, err := client.CreateTriggerDefinition(*input)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeSpec[opslevel.CustomActionsTriggerDefinitionUpdateInput](m)
+			if err != nil {
+				return err
+			}
+			input.Id = id
+			_, err = client.UpdateTriggerDefinition(*input)
+			return err
+		},
+	},
+	{
+		kind: ResourceKindCheck,
+		list: func(client *opslevel.Client) ([]listedResource, error) {
+			resp, err := client.ListChecks(nil)
+			if err != nil {
+				return nil, err
+			}
+			var output []listedResource
+			for _, item := range resp.Nodes {
+				output = append(output, listedResource{
+					Id:   item.Id,
+					Name: item.Name,
+					Spec: normalizeCheck(item),
+				})
+			}
+			return output, nil
+		},
+		create: func(client *opslevel.Client, m *ResourceManifest) (opslevel.ID, error) {
+			input, err := decodeCheckSpec(m)
+			if err != nil {
+				return "", err
+			}
+			result, err := createCheck(*input, false)
+			if err != nil {
+				return "", err
+			}
+			return result.Id, nil
+		},
+		update: func(client *opslevel.Client, id opslevel.ID, m *ResourceManifest) error {
+			input, err := decodeCheckSpec(m)
+			if err != nil {
+				return err
+			}
+			input.Spec["id"] = id
+			_, err = updateCheck(*input, false)
+			return err
+		},
+	},
+}
+
+// ensureAlias adds the manifest alias to a newly created resource unless OpsLevel already generated it
+func ensureAlias(client *opslevel.Client, id opslevel.ID, aliases []string, alias string) error {
+	if alias == "" || slices.Contains(aliases, alias) {
+		return nil
+	}
+	_, err := client.CreateAlias(opslevel.AliasCreateInput{
+		OwnerId: id,
+		Alias:   alias,
+	})
+	return err
+}
+
+// index lists every existing resource of the handler's kind keyed by alias and name
+func (handler *resourceHandler) index(client *opslevel.Client) (resourceIndex, error) {
+	resources, err := handler.list(client)
+	if err != nil {
+		return nil, err
+	}
+	index := resourceIndex{}
+	for _, item := range resources {
+		index.add(item.Id, append(slices.Clone(item.Aliases), item.Name)...)
+	}
+	return index, nil
+}
+
+// sortTeamManifests orders the teams by how many of their ancestors are also in the manifests so that
+// a parent team is always applied before its children, teams keep their file order otherwise
+func sortTeamManifests(manifests []*ResourceManifest) {
+	teams := map[string]int{}
+	for i, manifest := range manifests {
+		name := strings.ToLower(manifest.Name())
+		for _, key := range []string{strings.ToLower(manifest.Alias), name, strings.ReplaceAll(name, " ", "_")} {
+			if key != "" {
+				teams[key] = i
+			}
+		}
+	}
+	parentOf := func(i int) (int, bool) {
+		var spec struct {
+			ParentTeam struct {
+				Alias string `yaml:"alias"`
+			} `yaml:"parentTeam"`
+		}
+		_ = manifests[i].Spec.Decode(&spec)
+		parent, ok := teams[strings.ToLower(spec.ParentTeam.Alias)]
+		return parent, ok && spec.ParentTeam.Alias != ""
+	}
+	depths := map[*ResourceManifest]int{}
+	for i, manifest := range manifests {
+		// a cycle of parents is left for the API to reject
+		seen := map[int]bool{i: true}
+		for parent, ok := parentOf(i); ok && !seen[parent]; parent, ok = parentOf(parent) {
+			seen[parent] = true
+			depths[manifest]++
+		}
+	}
+	slices.SortStableFunc(manifests, func(a, b *ResourceManifest) int {
+		return cmp.Compare(depths[a], depths[b])
+	})
+}
+
+func getResourceHandler(kind ResourceKind) (*resourceHandler, bool) {
+	for i := range resourceHandlers {
+		if resourceHandlers[i].kind == kind {
+			return &resourceHandlers[i], true
+		}
+	}
+	return nil, false
+}
+
+func allResourceKinds() []string {
+	var output []string
+	for _, handler := range resourceHandlers {
+		output = append(output, string(handler.kind))
+	}
+	return output
+}
+
+func decodePropertyDefinitionSpec(m *ResourceManifest) (*opslevel.PropertyDefinitionInput, error) {
+	data, err := decodeSpec[opslevel.JSONSchema](m)
+	if err != nil {
+		return nil, err
+	}
+	return toPropertyDefinitionInput(*data)
+}
+
+// decodeCheckSpec converts a 'check' manifest back into the format used by 'opslevel create check'
+func decodeCheckSpec(m *ResourceManifest) (*CheckInputType, error) {
+	spec, err := decodeSpec[map[string]interface{}](m)
+	if err != nil {
+		return nil, err
+	}
+	checkType, ok := (*spec)["type"].(string)
+	if !ok || !slices.Contains(opslevel.AllCheckType, checkType) {
+		return nil, fmt.Errorf("%s: check '%s' field 'type' must be one of\n%s", m.Source, m.Identifier(), opslevel.AllCheckType)
+	}
+	delete(*spec, "type")
+	return &CheckInputType{
+		Version: CheckConfigCurrentVersion,
+		Kind:    opslevel.CheckType(checkType),
+		Spec:    *spec,
+	}, nil
+}
+
+// readManifests reads every document from the given files, directories or stdin ('-')
+func readManifests(paths ...string) ([]ResourceManifest, error) {
+	var output []ResourceManifest
+	for _, path := range paths {
+		files, err := listManifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			manifests, err := readManifestFile(file)
+			if err != nil {
+				return nil, err
+			}
+			output = append(output, manifests...)
+		}
+	}
+	return output, nil
+}
+
+func listManifestFiles(path string) ([]string, error) {
+	if path == "-" {
+		return []string{path}, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+	var files []string
+	err = filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		switch strings.ToLower(filepath.Ext(file)) {
+		case ".yaml", ".yml":
+			if !entry.IsDir() {
+				files = append(files, file)
+			}
+		}
+		return nil
+	})
+	return files, err
+}
+
+func readManifestFile(file string) ([]ResourceManifest, error) {
+	var data []byte
+	var err error
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var output []ResourceManifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for i := 0; ; i++ {
+		var document yaml.Node
+		if err := decoder.Decode(&document); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+		manifest, err := parseManifest(&document, fmt.Sprintf("%s[%d]", file, i))
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, *manifest)
+	}
+	return output, nil
+}
+
+func parseManifest(document *yaml.Node, source string) (*ResourceManifest, error) {
+	var header struct {
+		Kind string `yaml:"kind"`
+	}
+	if err := document.Decode(&header); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+
+	// Documents in the 'opslevel create check' format
+	if slices.Contains(opslevel.AllCheckType, header.Kind) {
+		var check CheckInputType
+		if err := document.Decode(&check); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		if check.Version != CheckConfigCurrentVersion {
+			return nil, fmt.Errorf("%s: supported config version is '%s' but found '%s'",
+				source, CheckConfigCurrentVersion, check.Version)
+		}
+		if check.Spec == nil {
+			return nil, fmt.Errorf("%s: %s check has no 'spec'", source, check.Kind)
+		}
+		check.Spec["type"] = string(check.Kind)
+		manifest := &ResourceManifest{Version: ManifestCurrentVersion, Kind: ResourceKindCheck, Source: source}
+		if err := manifest.Spec.Encode(check.Spec); err != nil {
+			return nil, fmt.Errorf("%s: %w", source, err)
+		}
+		return manifest, nil
+	}
+
+	manifest := &ResourceManifest{}
+	if err := document.Decode(manifest); err != nil {
+		return nil, fmt.Errorf("%s: %w", source, err)
+	}
+	manifest.Source = source
+	if manifest.Version != ManifestCurrentVersion {
+		return nil, fmt.Errorf("%s: supported manifest version is '%s' but found '%s'",
+			source, ManifestCurrentVersion, manifest.Version)
+	}
+	if _, ok := getResourceHandler(manifest.Kind); !ok {
+		return nil, fmt.Errorf("%s: kind '%s' not one of\n%s", source, manifest.Kind, allResourceKinds())
+	}
+	if manifest.Identifier() == "" {
+		return nil, fmt.Errorf("%s: %s requires 'alias' or 'spec.name' to be set", source, manifest.Kind)
+	}
+	return manifest, nil
+}
@@ -1,10 +1,6 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
-	"fmt"
-	"os"
 	"sort"
 	"strings"
 
@@ -30,11 +26,7 @@ There are multiple output formats that are useful
 
 		data, err := client.GetServiceMaturityWithAlias(alias)
 		cobra.CheckErr(err)
-		if isYamlOutput() {
-			common.YamlPrint(*data)
-		} else {
-			writeOutput(client, []opslevel.ServiceMaturity{*data})
-		}
+		printResult(*data, maturityColumns(client)...)
 	},
 }
 
@@ -54,8 +46,7 @@ There are multiple output formats that are useful
 		client := getClientGQL()
 		response, err := client.ListServicesMaturity(nil)
 		cobra.CheckErr(err)
-
-		writeOutput(client, response.Nodes)
+		printList(response.Nodes, maturityColumns(client)...)
 	},
 }
 
@@ -85,29 +76,15 @@ func GetValues(s *opslevel.ServiceMaturity, fields ...string) []string {
 	return output
 }
 
-func writeOutput(client *opslevel.Client, data []opslevel.ServiceMaturity) {
-	headers := getCategoryHeaders(client)
-
-	if isJsonOutput() {
-		if len(data) == 1 {
-			common.JsonPrint(json.MarshalIndent(data[0], "", "    "))
-		} else {
-			common.JsonPrint(json.MarshalIndent(data, "", "    "))
-		}
-	} else if isCsvOutput() {
-		w := csv.NewWriter(os.Stdout)
-		w.Write(headers)
-		for _, item := range data {
-			w.Write(GetValues(&item, headers...))
-		}
-		w.Flush()
-	} else {
-		w := common.NewTabWriter(headers...)
-		for _, item := range data {
-			fmt.Fprintf(w, "%s\n", strings.Join(GetValues(&item, headers...), "\t"))
-		}
-		w.Flush()
+// maturityColumns has the overall level and a column per rubric category
+func maturityColumns(client *opslevel.Client) []common.Column {
+	var output []common.Column
+	for _, header := range getCategoryHeaders(client) {
+		output = append(output, columnOf(header, func(item opslevel.ServiceMaturity) string {
+			return strings.Join(GetValues(&item, header), "")
+		}))
 	}
+	return output
 }
 
 func getCategoryHeaders(client *opslevel.Client) []string {
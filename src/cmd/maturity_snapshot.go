@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/spf13/cobra"
+)
+
+var maturityCmd = &cobra.Command{
+	Use:   "maturity",
+	Short: "Track the maturity of services over time",
+	Long:  "Track the maturity of services over time",
+}
+
+var maturitySnapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the maturity of all services to a file",
+	Long: `Save the maturity of all services to a file
+
+The snapshot has the time it was taken, the rubric levels and the id, alias, owner and maturity report
+of every service as returned by the API so that it can be compared later with 'opslevel maturity diff'.`,
+	Example: `
+		opslevel maturity snapshot -o maturity-$(date +%F).json
+		`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path, err := cmd.Flags().GetString("output")
+		cobra.CheckErr(err)
+
+		client := getClientGQL()
+		levels, err := client.ListLevels(nil)
+		cobra.CheckErr(err)
+		services, err := client.ListServices(nil)
+		cobra.CheckErr(err)
+		maturity, err := client.ListServicesMaturity(nil)
+		cobra.CheckErr(err)
+
+		// the maturity list only has service names, services that share a name are fetched one by one
+		names := map[string]int{}
+		for _, service := range services.Nodes {
+			names[service.Name]++
+		}
+		maturityByName := map[string]opslevel.ServiceMaturity{}
+		for _, node := range maturity.Nodes {
+			maturityByName[node.Name] = node
+		}
+		snapshot := maturitySnapshot{
+			TakenAt:  time.Now().UTC(),
+			Levels:   levels.Nodes,
+			Services: make([]maturitySnapshotService, len(services.Nodes)),
+		}
+		errs := newExecutor("services").Run(len(services.Nodes), func(i int) error {
+			service := services.Nodes[i]
+			snapshot.Services[i] = maturitySnapshotService{Id: service.Id, Name: service.Name, Owner: service.Owner.Alias}
+			if len(service.Aliases) > 0 {
+				snapshot.Services[i].Alias = service.Aliases[0]
+			}
+			node, ok := maturityByName[service.Name]
+			if names[service.Name] > 1 {
+				ok = false
+				if alias := snapshot.Services[i].Alias; alias != "" {
+					result, err := client.GetServiceMaturityWithAlias(alias)
+					if err != nil {
+						return fmt.Errorf("error getting the maturity of service '%s': %w", alias, err)
+					}
+					node, ok = *result, true
+				}
+			}
+			if ok {
+				snapshot.Services[i].Maturity = &node
+			}
+			return nil
+		})
+		cobra.CheckErr(errors.Join(errs...))
+		data, err := json.MarshalIndent(snapshot, "", "  ")
+		cobra.CheckErr(err)
+		if path == "" || path == "-" {
+			fmt.Println(string(data))
+			return
+		}
+		cobra.CheckErr(os.WriteFile(path, append(data, '\n'), 0o644))
+		fmt.Printf("saved the maturity of %d services to '%s'\n", len(snapshot.Services), path)
+	},
+}
+
+var maturityDiffCmd = &cobra.Command{
+	Use:   "diff OLD NEW",
+	Short: "Compare two maturity snapshots",
+	Long: `Compare two maturity snapshots
+
+Lists every service whose overall level or category level moved up or down between the snapshots,
+followed by a rollup per owning team. Levels are ordered by their index in the rubric of the newer snapshot.
+Services are matched by id so renamed services are still compared and are shown by alias.
+Services that are only in one of the snapshots are not compared.
+
+Use --section to print only the services or the teams, csv and tsv output require it since
+each section has different columns.`,
+	Example: `
+		opslevel maturity diff maturity-2026-09-01.json maturity-2026-10-01.json
+		opslevel maturity diff maturity-2026-09-01.json maturity-2026-10-01.json -o markdown
+		opslevel maturity diff maturity-2026-09-01.json maturity-2026-10-01.json -o json | jq '.teams'
+		opslevel maturity diff maturity-2026-09-01.json maturity-2026-10-01.json -o csv --section teams > teams.csv
+		`,
+	Args: cobra.ExactArgs(2),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, err := cmd.Flags().GetString("output")
+		cobra.CheckErr(err)
+		section, err := cmd.Flags().GetString("section")
+		cobra.CheckErr(err)
+		switch {
+		case section != "" && section != "services" && section != "teams":
+			cobra.CheckErr(fmt.Errorf("unknown section '%s' - must be one of: services|teams", section))
+		case section == "" && (output == "csv" || output == "tsv"):
+			cobra.CheckErr(fmt.Errorf("%s output requires --section services or --section teams", output))
+		}
+		before, err := readMaturitySnapshot(args[0])
+		cobra.CheckErr(err)
+		after, err := readMaturitySnapshot(args[1])
+		cobra.CheckErr(err)
+
+		levelIndex := map[string]int{}
+		for _, level := range append(before.Levels, after.Levels...) {
+			levelIndex[level.Name] = level.Index
+		}
+		services := after.serviceLevels()
+		result := maturityDiff{
+			Changes: common.DiffMaturity(before.serviceLevels(), services, levelIndex),
+		}
+		result.Teams = common.RollupMaturityByTeam(services, result.Changes)
+
+		if !common.IsTabularOutput(output) {
+			switch section {
+			case "services":
+				cobra.CheckErr(common.NewPrinter(output).PrintItem(result.Changes))
+			case "teams":
+				cobra.CheckErr(common.NewPrinter(output).PrintItem(result.Teams))
+			default:
+				cobra.CheckErr(common.NewPrinter(output).PrintItem(result))
+			}
+			return
+		}
+		headings := section == "" && (output == "markdown" || output == "md")
+		if section != "teams" {
+			printMaturityChanges(output, headings, result.Changes)
+		}
+		if section == "" {
+			fmt.Println()
+		}
+		if section != "services" {
+			printMaturityTeams(output, headings, result.Teams)
+		}
+	},
+}
+
+func printMaturityChanges(output string, headings bool, changes []common.MaturityChange) {
+	if headings {
+		fmt.Printf("## Services\n\n")
+	}
+	cobra.CheckErr(common.NewPrinter(output,
+		common.NewColumn("SERVICE", "service"),
+		common.NewColumn("OWNER", "owner"),
+		common.NewColumn("CATEGORY", "category"),
+		common.NewColumn("FROM", "from"),
+		common.NewColumn("TO", "to"),
+		columnOf("CHANGE", func(item common.MaturityChange) string { return fmt.Sprintf("%+d", item.Change) }),
+	).PrintList(changes))
+}
+
+func printMaturityTeams(output string, headings bool, teams []common.TeamMaturityRollup) {
+	if headings {
+		fmt.Printf("## Teams\n\n")
+	}
+	cobra.CheckErr(common.NewPrinter(output,
+		common.NewColumn("TEAM", "team"),
+		common.NewColumn("SERVICES", "services"),
+		common.NewColumn("UP", "up"),
+		common.NewColumn("DOWN", "down"),
+		columnOf("NET", func(item common.TeamMaturityRollup) string { return fmt.Sprintf("%+d", item.Net) }),
+	).PrintList(teams))
+}
+
+func init() {
+	rootCmd.AddCommand(maturityCmd)
+	maturityCmd.AddCommand(maturitySnapshotCmd)
+	maturityCmd.AddCommand(maturityDiffCmd)
+
+	maturitySnapshotCmd.Flags().StringP("output", "o", "", "the file to write the snapshot to [default: stdout]")
+	maturityDiffCmd.Flags().StringP("output", "o", "text", "Output format.  One of: "+common.OutputFormats+" [default: text]")
+	maturityDiffCmd.Flags().String("section", "", "Only print one section. One of: services|teams [default: both]")
+}
+
+// maturitySnapshot is the maturity of all services at a point in time
+type maturitySnapshot struct {
+	TakenAt  time.Time                 `json:"takenAt"`
+	Levels   []opslevel.Level          `json:"levels"`
+	Services []maturitySnapshotService `json:"services"`
+}
+
+// maturitySnapshotService is the maturity of a service as returned by the API, Owner is the alias of the owning team
+//
+// Maturity is nil when the maturity of the service couldn't be matched to it by name.
+type maturitySnapshotService struct {
+	Id       opslevel.ID               `json:"id"`
+	Alias    string                    `json:"alias"`
+	Name     string                    `json:"name"`
+	Owner    string                    `json:"owner"`
+	Maturity *opslevel.ServiceMaturity `json:"maturity"`
+}
+
+type maturityDiff struct {
+	Changes []common.MaturityChange     `json:"changes"`
+	Teams   []common.TeamMaturityRollup `json:"teams"`
+}
+
+func readMaturitySnapshot(path string) (*maturitySnapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var snapshot maturitySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("'%s' is not a maturity snapshot: %w", path, err)
+	}
+	return &snapshot, nil
+}
+
+// serviceLevels returns the level per category of the services matched by id and shown by alias, or by name when they have none
+func (s *maturitySnapshot) serviceLevels() []common.ServiceLevels {
+	output := make([]common.ServiceLevels, len(s.Services))
+	for i, service := range s.Services {
+		output[i] = common.ServiceLevels{
+			Id:      string(service.Id),
+			Service: cmp.Or(service.Alias, service.Name),
+			Owner:   service.Owner,
+		}
+		if service.Maturity != nil {
+			output[i].Levels = maturityLevels(*service.Maturity)
+		}
+	}
+	return output
+}
+
+// maturityLevels flattens the maturity report of a service to the level name per category
+func maturityLevels(service opslevel.ServiceMaturity) map[string]string {
+	levels := map[string]string{common.OverallCategory: service.MaturityReport.OverallLevel.Name}
+	for _, breakdown := range service.MaturityReport.CategoryBreakdown {
+		levels[breakdown.Category.Name] = breakdown.Level.Name
+	}
+	return levels
+}
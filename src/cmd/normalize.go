@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"reflect"
+
+	"github.com/opslevel/opslevel-go/v2025"
+)
+
+// resourceSpec is an existing resource converted to the same shape as the input of its create command
+type resourceSpec map[string]any
+
+// set stores the value unless it is empty so that unset fields are omitted
+func (s resourceSpec) set(key string, value any) {
+	if value == nil {
+		return
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String, reflect.Map, reflect.Slice:
+		if v.Len() == 0 {
+			return
+		}
+	case reflect.Pointer:
+		if v.IsNil() {
+			return
+		}
+	}
+	s[key] = value
+}
+
+func identifierSpec(id opslevel.ID, aliases ...string) resourceSpec {
+	output := resourceSpec{}
+	output.set("id", string(id))
+	if len(aliases) > 0 {
+		output.set("alias", aliases[0])
+	}
+	return output
+}
+
+func normalizeTeam(team opslevel.Team) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", team.Name)
+	output.set("managerEmail", team.Manager.Email)
+	output.set("parentTeam", identifierSpec(team.ParentTeam.Id, team.ParentTeam.Alias))
+	output.set("responsibilities", team.Responsibilities)
+	var contacts []resourceSpec
+	for _, contact := range team.Contacts {
+		item := resourceSpec{}
+		item.set("type", string(contact.Type))
+		item.set("address", contact.Address)
+		item.set("displayName", contact.DisplayName)
+		contacts = append(contacts, item)
+	}
+	output.set("contacts", contacts)
+	return output
+}
+
+func normalizeService(service opslevel.Service) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", service.Name)
+	output.set("description", service.Description)
+	output.set("framework", service.Framework)
+	output.set("language", service.Language)
+	output.set("product", service.Product)
+	output.set("lifecycle", service.Lifecycle.Alias)
+	output.set("tier", service.Tier.Alias)
+	output.set("owner", identifierSpec(service.Owner.Id, service.Owner.Alias))
+	if service.Parent != nil {
+		output.set("parent", identifierSpec(service.Parent.Id, service.Parent.Aliases...))
+	}
+	return output
+}
+
+func normalizeSystem(system opslevel.System) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", system.Name)
+	output.set("description", system.Description)
+	output.set("note", system.Note)
+	output.set("ownerId", string(system.Owner.Id()))
+	output.set("owner", identifierSpec(system.Owner.Id(), system.Owner.Alias()))
+	output.set("parent", identifierSpec(system.Parent.Id, system.Parent.Aliases...))
+	return output
+}
+
+func normalizeDomain(domain opslevel.Domain) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", domain.Name)
+	output.set("description", domain.Description)
+	output.set("note", domain.Note)
+	output.set("ownerId", string(domain.Owner.Id()))
+	output.set("owner", identifierSpec(domain.Owner.Id(), domain.Owner.Alias()))
+	return output
+}
+
+func normalizeFilter(filter opslevel.Filter) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", filter.Name)
+	output.set("connective", string(filter.Connective))
+	var predicates []resourceSpec
+	for _, predicate := range filter.Predicates {
+		item := resourceSpec{}
+		item.set("key", string(predicate.Key))
+		item.set("keyData", predicate.KeyData)
+		item.set("type", string(predicate.Type))
+		item.set("value", predicate.Value)
+		item.set("caseSensitive", predicate.CaseSensitive)
+		predicates = append(predicates, item)
+	}
+	output.set("predicates", predicates)
+	return output
+}
+
+func normalizeCategory(category opslevel.Category) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", category.Name)
+	return output
+}
+
+func normalizeLevel(level opslevel.Level) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", level.Name)
+	output.set("description", level.Description)
+	output.set("index", level.Index)
+	return output
+}
+
+func normalizeScorecard(scorecard opslevel.Scorecard) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", scorecard.Name)
+	output.set("description", scorecard.Description)
+	output.set("ownerId", string(scorecard.Owner.Id()))
+	output.set("owner", identifierSpec(scorecard.Owner.Id(), scorecard.Owner.Alias()))
+	output.set("filterId", string(scorecard.Filter.Id))
+	if scorecard.Filter.Id != "" {
+		output.set("filter", identifierSpec(scorecard.Filter.Id, scorecard.Filter.Alias()))
+	}
+	output.set("affectsOverallServiceLevels", scorecard.AffectsOverallServiceLevels)
+	return output
+}
+
+func normalizePropertyDefinition(definition opslevel.PropertyDefinition) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", definition.Name)
+	output.set("description", definition.Description)
+	output.set("schema", map[string]any(definition.Schema))
+	output.set("propertyDisplayStatus", string(definition.PropertyDisplayStatus))
+	return output
+}
+
+func normalizeInfra(infra opslevel.InfrastructureResource) resourceSpec {
+	provider := resourceSpec{}
+	provider.set("account", infra.ProviderData.AccountName)
+	provider.set("name", infra.ProviderData.ProviderName)
+	provider.set("type", infra.ProviderType)
+	provider.set("url", infra.ProviderData.ExternalUrl)
+
+	output := resourceSpec{}
+	output.set("schema", infra.Schema.Type)
+	output.set("owner", string(infra.Owner.Id()))
+	output.set("provider", provider)
+	output.set("data", map[string]any(infra.Data))
+	return output
+}
+
+func normalizeAction(action opslevel.CustomActionsExternalAction) resourceSpec {
+	output := resourceSpec{}
+	output.set("name", action.Name)
+	output.set("description", action.Description)
+	output.set("webhookUrl", action.WebhookUrl)
+	output.set("httpMethod", string(action.HttpMethod))
+	output.set("headers", map[string]any(action.Headers))
+	output.set("liquidTemplate", action.LiquidTemplate)
+	return output
+}
+
+// normalizeCheck uses the same format as 'opslevel create check' with the check type stored in the spec
+func normalizeCheck(check opslevel.Check) resourceSpec {
+	input := marshalCheck(check)
+	output := resourceSpec{}
+	for key, value := range input.Spec {
+		output.set(key, value)
+	}
+	output["type"] = string(input.Kind)
+	return output
+}
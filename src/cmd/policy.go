@@ -5,15 +5,17 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"math"
 	"net/url"
 	"os"
-	"path/filepath"
 	"strings"
 
 	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/loader"
 	"github.com/open-policy-agent/opa/v1/rego"
 	"github.com/open-policy-agent/opa/v1/types"
+	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
 	"github.com/relvacode/iso8601"
 	"github.com/rs/zerolog/log"
@@ -40,6 +42,32 @@ type githubResponse struct {
 	Languages   map[string]float64 `json:"languages,omitempty"`
 }
 
+type bitbucketResponse struct {
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Language    string `json:"language,omitempty"`
+}
+
+type azureDevopsResponse struct {
+	Id      string `json:"id,omitempty"`
+	Name    string `json:"name,omitempty"`
+	Project struct {
+		Description string `json:"description,omitempty"`
+	} `json:"project"`
+	Language  string             `json:"-"`
+	Languages map[string]float64 `json:"-"`
+}
+
+type azureDevopsLanguageMetrics struct {
+	RepositoryLanguageAnalytics []struct {
+		Id                string `json:"id"`
+		LanguageBreakdown []struct {
+			Name               string  `json:"name"`
+			LanguagePercentage float64 `json:"languagePercentage"`
+		} `json:"languageBreakdown"`
+	} `json:"repositoryLanguageAnalytics"`
+}
+
 type commonRepoMetadata struct {
 	Name        string             `json:"name,omitempty"`
 	Description string             `json:"description,omitempty"`
@@ -48,7 +76,7 @@ type commonRepoMetadata struct {
 }
 
 type astMarshallable interface {
-	commonRepoMetadata | opslevel.Level
+	commonRepoMetadata | opslevel.Level | opslevel.Service | opslevel.Team | []opslevel.Service | []opslevel.Tag | map[string]any
 }
 
 func (r *githubResponse) toRepoMetadata() commonRepoMetadata {
@@ -69,6 +97,28 @@ func (r *gitlabResponse) toRepoMetadata() commonRepoMetadata {
 	}
 }
 
+func (r *bitbucketResponse) toRepoMetadata() commonRepoMetadata {
+	return commonRepoMetadata{
+		Name:        r.Name,
+		Description: r.Description,
+		Language:    r.Language,
+	}
+}
+
+func (r *azureDevopsResponse) toRepoMetadata() commonRepoMetadata {
+	return commonRepoMetadata{
+		Name:        r.Name,
+		Description: r.Project.Description,
+		Language:    r.Language,
+		Languages:   r.Languages,
+	}
+}
+
+// policyProviderUrl is the base url of a git provider API without a trailing slash
+func policyProviderUrl(key string) string {
+	return strings.TrimSuffix(viper.GetString(key), "/")
+}
+
 func toASTValue[T astMarshallable](input T) (ast.Value, error) {
 	marshalData, err := json.Marshal(input)
 	if err != nil {
@@ -115,10 +165,36 @@ var policyCmd = &cobra.Command{
 Examples:
     opslevel run policy -f policy.rego | jq
     opslevel run policy -f policy.rego -i /tmp/input.json -o ./output.json
+    opslevel run policy -f ./policies -f ./data.yaml --query data.opslevel.deny
+    opslevel run policy -f bundle.tar.gz --query 'x := data.opslevel.services[_]'
+    opslevel run policy -f policy.rego --root ./monorepo --exclude .git --exclude node_modules --exclude vendor
+
+Every --file can be a Rego module, a JSON or YAML data document, a directory of them or an OPA bundle tarball (.tar.gz).
+Data documents in directories are loaded under the path of their directory like an OPA bundle.
+
+When the query has a single result with a single expression its value is written as is,
+otherwise every result set is written with its expressions and variable bindings.
+
+Policies can compare the repository with the catalog using opslevel.service(alias), opslevel.team(alias),
+opslevel.service_properties(alias), opslevel.service_tags(alias), opslevel.services_by_filter(id) and
+opslevel.graphql(query, variables). Their results are memoized so every call is only requested once per evaluation.
+
+Repository metadata is read with opslevel.repo.github(org, repo), opslevel.repo.gitlab(path),
+opslevel.repo.bitbucket(workspace, repo) and opslevel.repo.azure_devops(organization, project, repo).
+Self-hosted instances are used by setting --github-url, --gitlab-url, --bitbucket-url or --azure-devops-url
+or the keys of the same name in the profile.
+
+Files under --root are read with opslevel.read_file(path) as lines, or parsed with opslevel.parse_yaml(path),
+opslevel.parse_json(path), opslevel.parse_toml(path) and opslevel.parse_dockerfile(path). opslevel.glob(pattern)
+lists the files that match a pattern like "**.go" or "deploy/*.yaml". The files matching --exclude are skipped
+by input.files and opslevel.glob, .git and node_modules are excluded by default and giving --exclude replaces
+the defaults so they have to be repeated to keep them excluded.
 	`,
 	Run: func(cmd *cobra.Command, args []string) {
 		flags := cmd.Flags()
-		filePath, err := flags.GetString("file")
+		filePaths, err := flags.GetStringArray("file")
+		cobra.CheckErr(err)
+		query, err := flags.GetString("query")
 		cobra.CheckErr(err)
 		inputFilePath, err := flags.GetString("input")
 		cobra.CheckErr(err)
@@ -130,61 +206,34 @@ Examples:
 		}
 		outputFilePath, err := flags.GetString("output")
 		cobra.CheckErr(err)
-		policy, err := os.ReadFile(filePath)
+		policy, err := loadPolicy(filePaths)
+		cobra.CheckErr(err)
+		root, err := flags.GetString("root")
+		cobra.CheckErr(err)
+		exclude, err := flags.GetStringArray("exclude")
 		cobra.CheckErr(err)
+		policyFiles = common.FileTree{Root: root, Exclude: exclude}
 		input := regoInput{}
-		err = filepath.Walk(".",
-			func(path string, info os.FileInfo, err error) error {
-				if err != nil {
-					return err
-				}
-				input.Files = append(input.Files, path)
-				return nil
-			})
+		input.Files, err = policyFiles.Walk()
 		cobra.CheckErr(err)
 		input.Data = *inputJSON
-		rego := rego.New(
-			rego.Query("data.opslevel"),
-			rego.Module("test.rego",
-				string(policy),
-			),
-			rego.Function1(
-				&rego.Function{
-					Name: "opslevel.read_file",
-					Decl: types.NewFunction(types.Args(types.S), types.S),
-				},
-				RegoFuncReadFile),
-			rego.Function2(
-				&rego.Function{
-					Name:    "opslevel.repo.github",
-					Decl:    types.NewFunction(types.Args(types.S, types.S), types.A),
-					Memoize: true,
-				},
-				RegoFuncGetGithubRepo),
-			rego.Function1(
-				&rego.Function{
-					Name:    "opslevel.repo.gitlab",
-					Decl:    types.NewFunction(types.Args(types.S), types.A),
-					Memoize: true,
-				},
-				RegoFuncGetGitlabRepo),
-			rego.Function1(
-				&rego.Function{
-					Name: "opslevel.service_maturity_level",
-					Decl: types.NewFunction(types.Args(types.S), types.A),
-				},
-				RegoFuncGetMaturity),
-			rego.Function2(
-				&rego.Function{
-					Name: "opslevel.time.diff",
-					Decl: types.NewFunction(types.Args(types.S, types.S), types.A),
-				},
-				RegoFuncTimeDiff),
+		store, err := policy.Store()
+		cobra.CheckErr(err)
+		options := []func(*rego.Rego){
+			rego.Query(query),
+			rego.Store(store),
 			rego.Input(input),
-		)
-		rs, err := rego.Eval(context.Background())
+		}
+		for name, module := range policy.ParsedModules() {
+			options = append(options, rego.ParsedModule(module))
+			log.Debug().Msgf("loaded policy module '%s'", name)
+		}
+		for _, builtin := range policyBuiltins {
+			options = append(options, builtin.Register(builtin.Function))
+		}
+		rs, err := rego.New(options...).Eval(context.Background())
 		cobra.CheckErr(err)
-		b, err := json.Marshal(rs[0].Expressions[0].Value) // TODO: need more advanced handling of multiple things in json and reading from stdin
+		b, err := json.Marshal(policyOutput(rs))
 		cobra.CheckErr(err)
 
 		if outputFilePath == "-" {
@@ -197,12 +246,225 @@ Examples:
 	},
 }
 
+// policyFiles are the files that input.files lists and the file builtins read
+var policyFiles = common.FileTree{Root: "."}
+
+// policyBuiltin is a custom Rego function that policies can call and how to register its implementation
+type policyBuiltin struct {
+	Function *rego.Function
+	Register func(*rego.Function) func(*rego.Rego)
+	// Network is true when the builtin calls an API, 'opslevel test policy --strict-mocks' fails it unless it is mocked
+	Network bool
+}
+
+var policyBuiltins = []policyBuiltin{
+	{
+		Function: &rego.Function{
+			Name: "opslevel.read_file",
+			Decl: types.NewFunction(types.Args(types.S), types.S),
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncReadFile) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.parse_yaml",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncParseYaml) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.parse_json",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncParseJson) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.parse_toml",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncParseToml) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.parse_dockerfile",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncParseDockerfile) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.glob",
+			Decl:    types.NewFunction(types.Args(types.S), types.NewArray(nil, types.S)),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGlob) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.repo.github",
+			Decl:    types.NewFunction(types.Args(types.S, types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function2(f, RegoFuncGetGithubRepo) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.repo.gitlab",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetGitlabRepo) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.repo.bitbucket",
+			Decl:    types.NewFunction(types.Args(types.S, types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function2(f, RegoFuncGetBitbucketRepo) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.repo.azure_devops",
+			Decl:    types.NewFunction(types.Args(types.S, types.S, types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function3(f, RegoFuncGetAzureDevopsRepo) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name: "opslevel.service_maturity_level",
+			Decl: types.NewFunction(types.Args(types.S), types.A),
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetMaturity) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name: "opslevel.time.diff",
+			Decl: types.NewFunction(types.Args(types.S, types.S), types.A),
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function2(f, RegoFuncTimeDiff) },
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.service",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetService) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.team",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetTeam) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.service_properties",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetServiceProperties) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.service_tags",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncGetServiceTags) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.services_by_filter",
+			Decl:    types.NewFunction(types.Args(types.S), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function1(f, RegoFuncListServicesByFilter) },
+		Network:  true,
+	},
+	{
+		Function: &rego.Function{
+			Name:    "opslevel.graphql",
+			Decl:    types.NewFunction(types.Args(types.S, types.NewObject(nil, types.NewDynamicProperty(types.S, types.A))), types.A),
+			Memoize: true,
+		},
+		Register: func(f *rego.Function) func(*rego.Rego) { return rego.Function2(f, RegoFuncGraphQL) },
+		Network:  true,
+	},
+}
+
+// loadPolicy loads the Rego modules and data documents of every path, "-" reads a single module from stdin
+func loadPolicy(paths []string) (*loader.Result, error) {
+	var files []string
+	var stdin []byte
+	for _, path := range paths {
+		if path != "-" {
+			files = append(files, path)
+			continue
+		}
+		if isStdInFromTerminal() {
+			log.Info().Msg("Reading policy directly from command line... Press CTRL+D to stop typing")
+		}
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			return nil, err
+		}
+		stdin = data
+	}
+	result, err := loader.NewFileLoader().All(files)
+	if err != nil {
+		return nil, err
+	}
+	if stdin != nil {
+		module, err := ast.ParseModule("stdin.rego", string(stdin))
+		if err != nil {
+			return nil, err
+		}
+		result.Modules["stdin.rego"] = &loader.RegoFile{Name: "stdin.rego", Parsed: module, Raw: stdin}
+	}
+	if len(result.Modules) == 0 {
+		return nil, fmt.Errorf("no Rego modules found in: %s", strings.Join(paths, ", "))
+	}
+	return result, nil
+}
+
+// policyOutput keeps the output of queries with a single value, like the default query, to just that value
+func policyOutput(rs rego.ResultSet) any {
+	if len(rs) == 1 && len(rs[0].Expressions) == 1 && len(rs[0].Bindings) == 0 {
+		return rs[0].Expressions[0].Value
+	}
+	if rs == nil {
+		return rego.ResultSet{}
+	}
+	return rs
+}
+
 func RegoFuncReadFile(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
 	if str, ok := a.Value.(ast.String); ok {
-		if _, err := os.Stat(string(str)); err != nil {
+		path := policyFiles.Path(string(str))
+		if _, err := os.Stat(path); err != nil {
 			log.Warn().Msgf("%s", err)
 		} else {
-			file, err := os.Open(string(str))
+			file, err := os.Open(path)
 			defer file.Close()
 			if err != nil {
 				log.Error().Err(err).Msg("")
@@ -245,7 +507,7 @@ func RegoFuncGetGithubRepo(ctx rego.BuiltinContext, a, b *ast.Term) (*ast.Term,
 
 	githubToken := viper.GetString("github-token")
 	authorizationHeader := fmt.Sprintf("token %s", githubToken)
-	githubAPIUrl := fmt.Sprintf("https://api.github.com/repos/%v/%v", org, repo)
+	githubAPIUrl := fmt.Sprintf("%s/repos/%v/%v", policyProviderUrl("github-url"), org, repo)
 
 	var result githubResponse
 
@@ -276,7 +538,7 @@ func RegoFuncGetGithubRepo(ctx rego.BuiltinContext, a, b *ast.Term) (*ast.Term,
 	}
 
 	if languagesResponse.IsError() {
-		err := fmt.Errorf("error requesting Github repo languages. CODE: %d: REASON: %s", response.StatusCode(), response)
+		err := fmt.Errorf("error requesting Github repo languages. CODE: %d: REASON: %s", languagesResponse.StatusCode(), languagesResponse)
 		log.Error().Err(err).Msgf("")
 		return nil, err
 	}
@@ -307,7 +569,7 @@ func RegoFuncGetGitlabRepo(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, err
 
 	escapedPath := url.QueryEscape(path)
 	gitlabToken := viper.GetString("gitlab-token")
-	gitlabAPIUrl := fmt.Sprintf("https://gitlab.com/api/v4/projects/%s", escapedPath)
+	gitlabAPIUrl := fmt.Sprintf("%s/projects/%s", policyProviderUrl("gitlab-url"), escapedPath)
 
 	var result gitlabResponse
 
@@ -336,11 +598,136 @@ func RegoFuncGetGitlabRepo(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, err
 	}
 
 	if languagesResponse.IsError() {
-		err := fmt.Errorf("error requesting Gitlab repo languages. CODE: %d: REASON: %s", response.StatusCode(), response)
+		err := fmt.Errorf("error requesting Gitlab repo languages. CODE: %d: REASON: %s", languagesResponse.StatusCode(), languagesResponse)
+		log.Error().Err(err).Msgf("")
+		return nil, err
+	}
+
+	result.Language = getKeyFromMapByMaxValue(result.Languages)
+	repoMetadata := result.toRepoMetadata()
+	v, err := toASTValue(repoMetadata)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+func RegoFuncGetBitbucketRepo(ctx rego.BuiltinContext, a, b *ast.Term) (*ast.Term, error) {
+	var workspace, repo string
+	if err := ast.As(a.Value, &workspace); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	if err := ast.As(b.Value, &repo); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	if workspace == "" || repo == "" {
+		err := fmt.Errorf("opslevel.repo.bitbucket(\"%s\", \"%s\") failed: Please provide a valid workspace and repo", workspace, repo)
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	bitbucketAPIUrl := fmt.Sprintf("%s/repositories/%s/%s", policyProviderUrl("bitbucket-url"), url.PathEscape(workspace), url.PathEscape(repo))
+
+	var result bitbucketResponse
+
+	request := getClientRest().R().SetResult(&result)
+	if bitbucketToken := viper.GetString("bitbucket-token"); bitbucketToken != "" {
+		request.SetAuthToken(bitbucketToken)
+	}
+	response, err := request.Get(bitbucketAPIUrl)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	if response.IsError() {
+		err := fmt.Errorf("error requesting Bitbucket repo metadata. CODE: %d: REASON: %s", response.StatusCode(), response)
+		log.Error().Err(err).Msgf("")
+		return nil, err
+	}
+
+	repoMetadata := result.toRepoMetadata()
+	v, err := toASTValue(repoMetadata)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+// RegoFuncGetAzureDevopsRepo reads the languages of the repo from the language metrics of its project
+func RegoFuncGetAzureDevopsRepo(ctx rego.BuiltinContext, a, b, c *ast.Term) (*ast.Term, error) {
+	var organization, project, repo string
+	if err := ast.As(a.Value, &organization); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	if err := ast.As(b.Value, &project); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	if err := ast.As(c.Value, &repo); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	if organization == "" || project == "" || repo == "" {
+		err := fmt.Errorf("opslevel.repo.azure_devops(\"%s\", \"%s\", \"%s\") failed: Please provide a valid organization, project and repo", organization, project, repo)
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	azureDevopsAPIUrl := fmt.Sprintf("%s/%s/%s/_apis", policyProviderUrl("azure-devops-url"), url.PathEscape(organization), url.PathEscape(project))
+	azureDevopsToken := viper.GetString("azure-devops-token")
+
+	var result azureDevopsResponse
+
+	response, err := getClientRest().R().
+		SetBasicAuth("", azureDevopsToken).
+		SetQueryParam("api-version", "7.0").
+		SetResult(&result).
+		Get(azureDevopsAPIUrl + "/git/repositories/" + url.PathEscape(repo))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	if response.IsError() {
+		err := fmt.Errorf("error requesting Azure DevOps repo metadata. CODE: %d: REASON: %s", response.StatusCode(), response)
+		log.Error().Err(err).Msgf("")
+		return nil, err
+	}
+
+	var metrics azureDevopsLanguageMetrics
+	languagesResponse, err := getClientRest().R().
+		SetBasicAuth("", azureDevopsToken).
+		SetQueryParam("api-version", "7.0-preview.1").
+		SetResult(&metrics).
+		Get(azureDevopsAPIUrl + "/projectanalysis/languagemetrics")
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	if languagesResponse.IsError() {
+		err := fmt.Errorf("error requesting Azure DevOps repo languages. CODE: %d: REASON: %s", languagesResponse.StatusCode(), languagesResponse)
 		log.Error().Err(err).Msgf("")
 		return nil, err
 	}
 
+	for _, analytics := range metrics.RepositoryLanguageAnalytics {
+		if analytics.Id != result.Id {
+			continue
+		}
+		result.Languages = map[string]float64{}
+		for _, language := range analytics.LanguageBreakdown {
+			result.Languages[language.Name] = language.LanguagePercentage
+		}
+	}
 	result.Language = getKeyFromMapByMaxValue(result.Languages)
 	repoMetadata := result.toRepoMetadata()
 	v, err := toASTValue(repoMetadata)
@@ -409,13 +796,28 @@ func RegoFuncTimeDiff(ctx rego.BuiltinContext, s *ast.Term, e *ast.Term) (*ast.T
 func init() {
 	runCmd.AddCommand(policyCmd)
 
-	policyCmd.Flags().StringP("file", "f", "-", "File to read Rego policy from. Defaults to reading from stdin.")
+	policyCmd.Flags().StringArrayP("file", "f", []string{"-"}, "File, directory or bundle tarball to read Rego policy and data from, can be given multiple times. Defaults to reading from stdin.")
+	policyCmd.Flags().StringP("query", "q", "data.opslevel", "The Rego query to evaluate")
+	policyCmd.Flags().String("root", ".", "The directory that input.files lists and the file builtins read relative paths from")
+	policyCmd.Flags().StringArray("exclude", []string{".git", "node_modules"}, "A glob pattern of the files and directories to skip, matched against the relative path and the name, can be given multiple times")
 	policyCmd.Flags().StringP("input", "i", "", "File to read extra JSON data input to be used in Rego policy. Defaults to not reading anything.")
 	policyCmd.Flags().StringP("output", "o", "-", "File to write Rego policy output to. Defaults to writing to stdout.")
 	policyCmd.PersistentFlags().String("github-token", "", "The Github API token to use when calling opslevel.repo.github function within a Rego policy. Overrides environment variable 'GITHUB_API_TOKEN'")
 	policyCmd.PersistentFlags().String("gitlab-token", "", "The Gitlab API token to use when calling opslevel.repo.gitlab function within a Rego policy. Overrides environment variable 'GITLAB_API_TOKEN'")
+	policyCmd.PersistentFlags().String("bitbucket-token", "", "The Bitbucket access token to use when calling opslevel.repo.bitbucket function within a Rego policy. Overrides environment variable 'BITBUCKET_API_TOKEN'")
+	policyCmd.PersistentFlags().String("azure-devops-token", "", "The Azure DevOps personal access token to use when calling opslevel.repo.azure_devops function within a Rego policy. Overrides environment variable 'AZURE_DEVOPS_API_TOKEN'")
+	policyCmd.PersistentFlags().String("github-url", "https://api.github.com", "The Github API url, for Github Enterprise Server use 'https://HOST/api/v3'. Overrides environment variable 'GITHUB_API_URL'")
+	policyCmd.PersistentFlags().String("gitlab-url", "https://gitlab.com/api/v4", "The Gitlab API url, for self-managed Gitlab use 'https://HOST/api/v4'. Overrides environment variable 'GITLAB_API_URL'")
+	policyCmd.PersistentFlags().String("bitbucket-url", "https://api.bitbucket.org/2.0", "The Bitbucket Cloud API url. Overrides environment variable 'BITBUCKET_API_URL'")
+	policyCmd.PersistentFlags().String("azure-devops-url", "https://dev.azure.com", "The Azure DevOps url, for Azure DevOps Server use 'https://HOST/tfs'. Overrides environment variable 'AZURE_DEVOPS_API_URL'")
 
 	viper.BindPFlags(policyCmd.PersistentFlags())
 	viper.BindEnv("github-token", "GITHUB_API_TOKEN")
 	viper.BindEnv("gitlab-token", "GITLAB_API_TOKEN")
+	viper.BindEnv("bitbucket-token", "BITBUCKET_API_TOKEN")
+	viper.BindEnv("azure-devops-token", "AZURE_DEVOPS_API_TOKEN")
+	viper.BindEnv("github-url", "GITHUB_API_URL")
+	viper.BindEnv("gitlab-url", "GITLAB_API_URL")
+	viper.BindEnv("bitbucket-url", "BITBUCKET_API_URL")
+	viper.BindEnv("azure-devops-url", "AZURE_DEVOPS_API_URL")
 }
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/rs/zerolog/log"
+)
+
+func RegoFuncGetService(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	alias, err := regoStringArg("opslevel.service", "alias", a)
+	if err != nil {
+		return nil, err
+	}
+
+	service, err := getService(alias)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	v, err := toASTValue(*service)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+func RegoFuncGetTeam(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	alias, err := regoStringArg("opslevel.team", "alias", a)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := GetTeam(alias)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	v, err := toASTValue(*team)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+// RegoFuncGetServiceProperties returns the values of the properties of a service by the alias of their definition
+func RegoFuncGetServiceProperties(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	alias, err := regoStringArg("opslevel.service_properties", "alias", a)
+	if err != nil {
+		return nil, err
+	}
+
+	client := getClientGQL()
+	service, err := getService(alias)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	properties, err := service.GetProperties(client, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	output := map[string]any{}
+	for _, property := range properties.Nodes {
+		key := string(property.Definition.Id)
+		if len(property.Definition.Aliases) > 0 {
+			key = property.Definition.Aliases[0]
+		}
+		var value any
+		if property.Value != nil {
+			if err := json.Unmarshal([]byte(*property.Value), &value); err != nil {
+				log.Error().Err(err).Msg("")
+				return nil, err
+			}
+		}
+		output[key] = value
+	}
+	v, err := toASTValue(output)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+func RegoFuncGetServiceTags(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	alias, err := regoStringArg("opslevel.service_tags", "alias", a)
+	if err != nil {
+		return nil, err
+	}
+
+	client := getClientGQL()
+	service, err := getService(alias)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	tags, err := service.GetTags(client, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	v, err := toASTValue(append([]opslevel.Tag{}, tags.Nodes...))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+func RegoFuncListServicesByFilter(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	id, err := regoStringArg("opslevel.services_by_filter", "filter id", a)
+	if err != nil {
+		return nil, err
+	}
+
+	services, err := getClientGQL().ListServicesWithFilter(id, nil)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	v, err := toASTValue(append([]opslevel.Service{}, services.Nodes...))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+// RegoFuncGraphQL runs any query against the OpsLevel API and returns its data
+func RegoFuncGraphQL(ctx rego.BuiltinContext, q, v *ast.Term) (*ast.Term, error) {
+	query, err := regoStringArg("opslevel.graphql", "query", q)
+	if err != nil {
+		return nil, err
+	}
+	variables := map[string]any{}
+	if err := ast.As(v.Value, &variables); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+
+	data, err := getClientGQL().ExecRaw(query, variables)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	value, err := ast.ValueFromReader(bytes.NewReader(data))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(value), nil
+}
+
+// regoStringArg reads a string argument of a builtin, an empty string is an error
+func regoStringArg(function string, name string, a *ast.Term) (string, error) {
+	var value string
+	if err := ast.As(a.Value, &value); err != nil {
+		log.Error().Err(err).Msg("")
+		return "", err
+	}
+	if value == "" {
+		err := fmt.Errorf("%s(\"%s\") failed: Please provide a valid %s", function, value, name)
+		log.Error().Err(err).Msg("")
+		return "", err
+	}
+	return value, nil
+}
@@ -0,0 +1,127 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/opslevel/cli/common"
+	"github.com/pelletier/go-toml/v2"
+	"github.com/rs/zerolog/log"
+	"gopkg.in/yaml.v3"
+)
+
+// RegoFuncParseYaml returns the document of a YAML file or a list of documents when the file has more than one
+func RegoFuncParseYaml(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	data, err := readPolicyFile("opslevel.parse_yaml", a)
+	if data == nil || err != nil {
+		return nil, err
+	}
+
+	var documents []any
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document any
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			log.Error().Err(err).Msg("")
+			return nil, err
+		}
+		documents = append(documents, document)
+	}
+	if len(documents) == 1 {
+		return policyValueTerm(documents[0])
+	}
+	return policyValueTerm(documents)
+}
+
+func RegoFuncParseJson(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	data, err := readPolicyFile("opslevel.parse_json", a)
+	if data == nil || err != nil {
+		return nil, err
+	}
+
+	v, err := ast.ValueFromReader(bytes.NewReader(data))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
+
+func RegoFuncParseToml(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	data, err := readPolicyFile("opslevel.parse_toml", a)
+	if data == nil || err != nil {
+		return nil, err
+	}
+
+	var document map[string]any
+	if err := toml.Unmarshal(data, &document); err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return policyValueTerm(document)
+}
+
+// RegoFuncParseDockerfile returns the instructions of a Dockerfile
+func RegoFuncParseDockerfile(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	data, err := readPolicyFile("opslevel.parse_dockerfile", a)
+	if data == nil || err != nil {
+		return nil, err
+	}
+
+	return policyValueTerm(common.ParseDockerfile(data))
+}
+
+// RegoFuncGlob returns the files under --root that match the pattern and are not excluded
+func RegoFuncGlob(ctx rego.BuiltinContext, a *ast.Term) (*ast.Term, error) {
+	pattern, err := regoStringArg("opslevel.glob", "pattern", a)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := policyFiles.Glob(pattern)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return policyValueTerm(files)
+}
+
+// readPolicyFile reads a file relative to --root, a file that doesn't exist is undefined like in opslevel.read_file
+func readPolicyFile(function string, a *ast.Term) ([]byte, error) {
+	name, err := regoStringArg(function, "path", a)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(policyFiles.Path(name))
+	if errors.Is(err, os.ErrNotExist) {
+		log.Warn().Msgf("%s", err)
+		return nil, nil
+	}
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return data, nil
+}
+
+// policyValueTerm converts a parsed document through JSON so values like TOML dates become strings
+func policyValueTerm(value any) (*ast.Term, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	v, err := ast.ValueFromReader(bytes.NewReader(data))
+	if err != nil {
+		log.Error().Err(err).Msg("")
+		return nil, err
+	}
+	return ast.NewTerm(v), nil
+}
@@ -0,0 +1,266 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/ast"
+	"github.com/open-policy-agent/opa/v1/cover"
+	"github.com/open-policy-agent/opa/v1/rego"
+	"github.com/open-policy-agent/opa/v1/tester"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var testPolicyCmd = &cobra.Command{
+	Use:   "policy [PATH...]",
+	Short: "Run the unit tests of Rego policies",
+	Long: `Run the unit tests of Rego policies
+
+Like 'opa test' every rule whose name starts with 'test_' is a test. The paths are loaded like the --file of
+'opslevel run policy' and default to the current directory.
+
+The opslevel.* builtins can be mocked with fixture files, or directories of them, in JSON or YAML.
+The fixture maps a builtin to the results it returns, the first mock whose args equal the arguments
+of the call is used and a mock without args matches every call. A mocked builtin fails the test when
+no mock matches, builtins without mocks call their real implementation unless --strict-mocks is set,
+then the builtins that call an API fail the test as well. Rego's own http.send can be mocked with 'with'.
+
+    opslevel.repo.github:
+      - args: ["opslevel", "cli"]
+        result: {name: cli, language: Go}
+    opslevel.service_maturity_level:
+      - args: ["cart"]
+        result: {name: Gold, index: 3}
+      - error: "service not found"
+`,
+	Example: `
+		opslevel test policy
+		opslevel test policy ./policies --mock ./policies/fixtures --coverage
+		opslevel test policy ./policies --mock ./policies/fixtures --strict-mocks --threshold 80
+		opslevel test policy ./policies --run 'test_deny_.*' -v
+		`,
+	Run: func(cmd *cobra.Command, args []string) {
+		paths := args
+		if len(paths) == 0 {
+			paths = []string{"."}
+		}
+		filter, err := cmd.Flags().GetString("run")
+		cobra.CheckErr(err)
+		verbose, err := cmd.Flags().GetBool("verbose")
+		cobra.CheckErr(err)
+		coverage, err := cmd.Flags().GetBool("coverage")
+		cobra.CheckErr(err)
+		threshold, err := cmd.Flags().GetFloat64("threshold")
+		cobra.CheckErr(err)
+		mockPaths, err := cmd.Flags().GetStringArray("mock")
+		cobra.CheckErr(err)
+		strictMocks, err := cmd.Flags().GetBool("strict-mocks")
+		cobra.CheckErr(err)
+		if threshold > 0 {
+			coverage = true
+		}
+
+		mocks, err := readPolicyMocks(mockPaths)
+		cobra.CheckErr(err)
+		policy, err := loadPolicy(paths)
+		cobra.CheckErr(err)
+		store, err := policy.Store()
+		cobra.CheckErr(err)
+		modules := policy.ParsedModules()
+		tracer := cover.New()
+		runner := tester.NewRunner().
+			SetModules(modules).
+			SetStore(store).
+			Filter(filter).
+			AddCustomBuiltins(testPolicyBuiltins(mocks, strictMocks)).
+			RaiseBuiltinErrors(true).
+			CapturePrintOutput(true)
+		if coverage {
+			runner.SetCoverageQueryTracer(tracer)
+		}
+		results, err := runner.RunTests(context.Background(), nil)
+		cobra.CheckErr(err)
+
+		var total, failed, skipped int
+		for result := range results {
+			total++
+			if result.Skip {
+				skipped++
+				fmt.Printf("SKIP  %s.%s\n", result.Package, result.Name)
+				continue
+			}
+			if result.Pass() {
+				if verbose {
+					fmt.Printf("PASS  %s.%s (%s)\n", result.Package, result.Name, result.Duration)
+				}
+				continue
+			}
+			failed++
+			fmt.Printf("FAIL  %s.%s (%s)\n", result.Package, result.Name, result.Duration)
+			if result.Error != nil {
+				fmt.Printf("      %s\n", result.Error)
+			}
+			if len(result.Output) > 0 {
+				fmt.Printf("      %s\n", strings.ReplaceAll(strings.TrimSpace(string(result.Output)), "\n", "\n      "))
+			}
+		}
+		if total == 0 {
+			cobra.CheckErr(fmt.Errorf("no tests found in: %s", strings.Join(paths, ", ")))
+		}
+		fmt.Printf("%d passed, %d failed, %d skipped\n", total-failed-skipped, failed, skipped)
+		if coverage {
+			report := tracer.Report(modules)
+			files := make([]string, 0, len(report.Files))
+			for file := range report.Files {
+				files = append(files, file)
+			}
+			slices.Sort(files)
+			for _, file := range files {
+				fmt.Printf("%6.2f%%  %s\n", report.Files[file].Coverage, file)
+			}
+			fmt.Printf("%6.2f%%  total coverage\n", report.Coverage)
+			if report.Coverage < threshold {
+				fmt.Printf("coverage is below the threshold of %.2f%%\n", threshold)
+				os.Exit(1)
+			}
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	testCmd.AddCommand(testPolicyCmd)
+
+	testPolicyCmd.Flags().StringP("run", "r", "", "only run the tests whose name matches this regular expression")
+	testPolicyCmd.Flags().BoolP("verbose", "v", false, "also list the tests that passed")
+	testPolicyCmd.Flags().Bool("coverage", false, "report the test coverage of every module")
+	testPolicyCmd.Flags().Float64("threshold", 0, "fail when the total test coverage in percent is below this value, implies --coverage")
+	testPolicyCmd.Flags().StringArray("mock", nil, "a fixture file or directory of fixture files that mock the opslevel.* builtins, can be given multiple times")
+	testPolicyCmd.Flags().Bool("strict-mocks", false, "fail the builtins that call an API when they have no mock instead of calling the API")
+}
+
+// policyMock is a canned result of a builtin, a mock without args matches every call
+type policyMock struct {
+	Args   []any  `yaml:"args"`
+	Result any    `yaml:"result"`
+	Error  string `yaml:"error"`
+}
+
+// readPolicyMocks reads the mocks of every fixture by the name of the builtin they replace
+func readPolicyMocks(paths []string) (map[string][]policyMock, error) {
+	var files []string
+	for _, path := range paths {
+		err := filepath.WalkDir(path, func(file string, entry fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch filepath.Ext(file) {
+			case ".json", ".yaml", ".yml":
+				files = append(files, file)
+			default:
+				if !entry.IsDir() && file == path {
+					files = append(files, file)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var names []string
+	for _, builtin := range policyBuiltins {
+		names = append(names, builtin.Function.Name)
+	}
+	output := map[string][]policyMock{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		var fixture map[string][]policyMock
+		if err := yaml.Unmarshal(data, &fixture); err != nil {
+			return nil, fmt.Errorf("invalid mock fixture '%s': %w", file, err)
+		}
+		for name, mocks := range fixture {
+			if !slices.Contains(names, name) {
+				return nil, fmt.Errorf("invalid mock fixture '%s': unknown builtin '%s' - must be one of: %s", file, name, strings.Join(names, ", "))
+			}
+			output[name] = append(output[name], mocks...)
+		}
+	}
+	return output, nil
+}
+
+// testPolicyBuiltins registers the opslevel.* builtins with the test runner, replacing the ones that have mocks
+//
+// When strict is set a network builtin without mocks gets an empty list of mocks so every call fails.
+func testPolicyBuiltins(mocks map[string][]policyMock, strict bool) []*tester.Builtin {
+	var output []*tester.Builtin
+	for _, builtin := range policyBuiltins {
+		register := builtin.Register(builtin.Function)
+		if mocks, ok := mocks[builtin.Function.Name]; ok || (strict && builtin.Network) {
+			register = rego.FunctionDyn(builtin.Function, mockPolicyBuiltin(builtin.Function, mocks))
+		}
+		output = append(output, &tester.Builtin{
+			Decl: &ast.Builtin{Name: builtin.Function.Name, Decl: builtin.Function.Decl},
+			Func: register,
+		})
+	}
+	return output
+}
+
+// mockPolicyBuiltin compares the arguments of a call with the args of each mock as JSON
+//
+// The terms of a dynamic builtin also hold its output so only the declared arguments are compared.
+func mockPolicyBuiltin(function *rego.Function, mocks []policyMock) rego.BuiltinDyn {
+	return func(ctx rego.BuiltinContext, terms []*ast.Term) (*ast.Term, error) {
+		if len(mocks) == 0 {
+			return nil, fmt.Errorf("%s has no mock and --strict-mocks is set", function.Name)
+		}
+		args := make([]any, len(function.Decl.FuncArgs().Args))
+		for i, term := range terms[:len(args)] {
+			value, err := ast.JSON(term.Value)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = value
+		}
+		actual, err := json.Marshal(args)
+		if err != nil {
+			return nil, err
+		}
+		for _, mock := range mocks {
+			if mock.Args != nil {
+				expected, err := json.Marshal(mock.Args)
+				if err != nil {
+					return nil, err
+				}
+				if !bytes.Equal(actual, expected) {
+					continue
+				}
+			}
+			if mock.Error != "" {
+				return nil, errors.New(mock.Error)
+			}
+			value, err := ast.InterfaceToValue(mock.Result)
+			if err != nil {
+				return nil, err
+			}
+			return ast.NewTerm(value), nil
+		}
+		return nil, fmt.Errorf("no mock of %s matches the arguments %s", function.Name, actual)
+	}
+}
@@ -1,11 +1,7 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/opslevel/opslevel-go/v2025"
 
@@ -61,11 +57,7 @@ var getPropertyCmd = &cobra.Command{
 			cobra.CheckErr(err)
 		}
 
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -84,28 +76,17 @@ var listPropertyCmd = &cobra.Command{
 		properties, err := service.GetProperties(getClientGQL(), nil)
 		cobra.CheckErr(err)
 
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(properties.Nodes, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"ID", "LOCKED", "ALIASES"})
-			for _, p := range properties.Nodes {
-				aliases := strings.Join(p.Definition.Aliases, "/")
-				w.Write([]string{string(p.Definition.Id), fmt.Sprintf("%t", p.Locked), aliases})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("ID", "LOCKED", "VALUE", "ALIASES")
-			for _, p := range properties.Nodes {
-				var valueOutput string
-				if p.Value != nil {
-					valueOutput = string(*p.Value)
+		printList(properties.Nodes,
+			common.NewColumn("ID", "definition.id"),
+			common.NewColumn("LOCKED", "locked"),
+			columnOf("VALUE", func(item opslevel.Property) string {
+				if item.Value == nil {
+					return ""
 				}
-				aliases := strings.Join(p.Definition.Aliases, ",")
-				fmt.Fprintf(w, "%s\t%t\t%s\t%s\n", string(p.Definition.Id), p.Locked, valueOutput, aliases)
-			}
-			w.Flush()
-		}
+				return string(*item.Value)
+			}),
+			common.NewAliasesColumn("definition.aliases"),
+		)
 	},
 }
 
@@ -222,7 +203,10 @@ func readPropertyDefinitionInput() (*opslevel.PropertyDefinitionInput, error) {
 	if err != nil {
 		return nil, err
 	}
-	data := *d
+	return toPropertyDefinitionInput(*d)
+}
+
+func toPropertyDefinitionInput(data opslevel.JSONSchema) (*opslevel.PropertyDefinitionInput, error) {
 	name, ok := data["name"].(string)
 	if !ok {
 		return nil, fmt.Errorf("name is required and must be a string")
@@ -259,12 +243,7 @@ var getPropertyDefinitionCmd = &cobra.Command{
 		identifier := args[0]
 		result, err := getClientGQL().GetPropertyDefinition(identifier)
 		cobra.CheckErr(err)
-
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -275,27 +254,14 @@ var listPropertyDefinitionsCmd = &cobra.Command{
 	Long:    "List property definitions",
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListPropertyDefinitions(nil)
-		list := resp.Nodes
-
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"ALIASES", "ID", "NAME", "DISPLAY_STATUS", "ALLOWED_IN_CONFIG_FILES"})
-			for _, d := range list {
-				aliases := strings.Join(d.Aliases, "/")
-				w.Write([]string{aliases, string(d.Id), d.Name, string(d.PropertyDisplayStatus), fmt.Sprintf("%t", d.AllowedInConfigFiles)})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("ALIASES", "ID", "NAME", "DISPLAY_STATUS", "ALLOWED_IN_CONFIG_FILES")
-			for _, d := range list {
-				aliases := strings.Join(d.Aliases, "/")
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%t\n", aliases, d.Id, d.Name, d.PropertyDisplayStatus, d.AllowedInConfigFiles)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewAliasesColumn("aliases"),
+			common.NewColumn("ID", "id"),
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("DISPLAY_STATUS", "propertyDisplayStatus"),
+			common.NewColumn("ALLOWED_IN_CONFIG_FILES", "allowedInConfigFiles"),
+		)
 	},
 }
 
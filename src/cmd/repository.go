@@ -1,9 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"github.com/opslevel/opslevel-go/v2025"
 
 	"github.com/opslevel/cli/common"
@@ -29,7 +26,7 @@ var getRepositoryCmd = &cobra.Command{
 			cobra.CheckErr(err)
 		}
 		cobra.CheckErr(err)
-		common.PrettyPrint(repository)
+		printResult(repository)
 	},
 }
 
@@ -49,17 +46,12 @@ var listRepositoryCmd = &cobra.Command{
 		payloadVars["visible"] = !hiddenOnly
 
 		resp, err := client.ListRepositories(&payloadVars)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.DefaultAlias, item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ALIAS", "defaultAlias"),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
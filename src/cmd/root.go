@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"fmt"
 	"os"
 	"strings"
 
@@ -37,9 +38,12 @@ func init() {
 	rootCmd.PersistentFlags().String("log-level", "INFO", "overrides environment variable 'OPSLEVEL_LOG_LEVEL' (options [\"ERROR\", \"WARN\", \"INFO\", \"DEBUG\"])")
 	rootCmd.PersistentFlags().String("api-url", "https://app.opslevel.com", "The OpsLevel API Url. Overrides environment variable 'OPSLEVEL_API_URL'")
 	rootCmd.PersistentFlags().String("api-token", "", "The OpsLevel API Token. Overrides environment variable 'OPSLEVEL_API_TOKEN'")
-	rootCmd.PersistentFlags().Bool("no-headers", false, "If --output=text and this flag is set the headers will be skip from being output")
+	rootCmd.PersistentFlags().String("api-token-command", "", "A command that prints the OpsLevel API Token, used to read the token from a secret manager. Overrides environment variable 'OPSLEVEL_API_TOKEN_COMMAND'")
+	rootCmd.PersistentFlags().Bool("no-headers", false, "If --output=text, csv or tsv and this flag is set the headers will be skip from being output")
 	rootCmd.PersistentFlags().Lookup("no-headers").NoOptDefVal = "true"
 	rootCmd.PersistentFlags().Int("api-timeout", 10, "The number of seconds to timeout of the request. Overrides environment variable 'OPSLEVEL_API_TIMEOUT'")
+	rootCmd.PersistentFlags().String("profile", "", "The profile from the config file to use. Overrides environment variable 'OPSLEVEL_PROFILE' [default: the current profile]")
+	rootCmd.PersistentFlags().Int("concurrency", 4, "The number of API requests to make in parallel for bulk commands like import. Overrides environment variable 'OPSLEVEL_CONCURRENCY'")
 
 	viper.BindPFlags(rootCmd.PersistentFlags())
 	viper.BindEnv("log-format", "OPSLEVEL_LOG_FORMAT", "OL_LOG_FORMAT", "OL_LOGFORMAT")
@@ -47,6 +51,9 @@ func init() {
 	viper.BindEnv("api-url", "OPSLEVEL_API_URL", "OL_API_URL", "OPSLEVEL_APP_URL", "OL_APP_URL")
 	viper.BindEnv("api-token", "OPSLEVEL_API_TOKEN", "OL_API_TOKEN", "OL_APITOKEN")
 	viper.BindEnv("api-timeout", "OPSLEVEL_API_TIMEOUT")
+	viper.BindEnv("api-token-command", "OPSLEVEL_API_TOKEN_COMMAND")
+	viper.BindEnv("concurrency", "OPSLEVEL_CONCURRENCY")
+	viper.BindEnv("profile", "OPSLEVEL_PROFILE")
 	cobra.OnInitialize(initConfig)
 }
 
@@ -54,6 +61,7 @@ func initConfig() {
 	viper.SetEnvPrefix("OPSLEVEL")
 	viper.AutomaticEnv()
 	setupLogging()
+	applyProfile()
 }
 
 func setupLogging() {
@@ -81,6 +89,7 @@ func setupLogging() {
 
 func getClientRest() *resty.Client {
 	if _clientRest == nil {
+		cobra.CheckErr(activeProfileErr)
 		_clientRest = opslevel.NewRestClient(opslevel.SetURL(viper.GetString("api-url")))
 	}
 	return _clientRest
@@ -88,7 +97,19 @@ func getClientRest() *resty.Client {
 
 func getClientGQL(options ...opslevel.Option) *opslevel.Client {
 	if _clientGQL == nil {
+		cobra.CheckErr(activeProfileErr)
+		token, _, err := resolveAPIToken()
+		cobra.CheckErr(err)
+		if token == "" {
+			cobra.CheckErr(fmt.Errorf("no OpsLevel API token found - run 'opslevel auth login' or set --api-token or 'OPSLEVEL_API_TOKEN'"))
+		}
+		viper.Set("api-token", token)
 		_clientGQL = common.NewGraphClient(version, options...)
 	}
 	return _clientGQL
 }
+
+// newExecutor returns an executor for bulk commands that runs --concurrency API requests in parallel
+func newExecutor(label string) *common.Executor {
+	return common.NewExecutor(viper.GetInt("concurrency"), label)
+}
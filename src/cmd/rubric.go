@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/opslevel/opslevel-go/v2025"
@@ -49,7 +48,7 @@ var getCategoryCmd = &cobra.Command{
 		key := args[0]
 		category, err := getClientGQL().GetCategory(opslevel.ID(key))
 		cobra.CheckErr(err)
-		common.PrettyPrint(category)
+		printResult(category)
 	},
 }
 
@@ -61,16 +60,11 @@ var listCategoryCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListCategories(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Alias(), item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			columnOf("ALIAS", func(item opslevel.Category) string { return item.Alias() }),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
@@ -125,7 +119,7 @@ var getLevelCmd = &cobra.Command{
 		key := args[0]
 		level, err := getClientGQL().GetLevel(opslevel.ID(key))
 		cobra.CheckErr(err)
-		common.PrettyPrint(level)
+		printResult(level)
 	},
 }
 
@@ -137,15 +131,11 @@ var listLevelCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListLevels(nil)
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(resp.Nodes, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range resp.Nodes {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Alias, item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ALIAS", "alias"),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
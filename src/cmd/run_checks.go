@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/spf13/cobra"
+)
+
+var runChecksCmd = &cobra.Command{
+	Use:   "checks [PATH]",
+	Short: "Evaluate the repository checks of a service against a local checkout",
+	Long: `Evaluate the repository checks of a service against a local checkout
+
+The enabled checks that apply to the service are fetched from OpsLevel and the Repo File, Repo Grep
+and Repo Search checks are evaluated against the working tree at PATH (default: the current directory)
+so failures can be fixed before pushing. Other check types are only evaluated by OpsLevel.
+
+PATH is the root of the repository checkout. Like OpsLevel the checks start at the base directory of
+the service in the repository, unless a Repo File check uses the absolute root. When the service has
+different base directories in its repositories use --repository to pick the one checked out at PATH.
+
+Exits with status 1 when a check fails.`,
+	Example: `
+		opslevel run checks --service cart
+		opslevel run checks --service cart ~/src/monorepo --repository github.com:acme/monorepo -o json
+		`,
+	Args: cobra.MaximumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		alias, err := cmd.Flags().GetString("service")
+		cobra.CheckErr(err)
+		output, err := cmd.Flags().GetString("output")
+		cobra.CheckErr(err)
+		repository, err := cmd.Flags().GetString("repository")
+		cobra.CheckErr(err)
+		root := "."
+		if len(args) > 0 {
+			root = args[0]
+		}
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			cobra.CheckErr(fmt.Errorf("'%s' is not a directory", root))
+		}
+
+		client := getClientGQL()
+		service, err := getService(alias)
+		cobra.CheckErr(err)
+		checks, err := listServiceChecks(client, service)
+		cobra.CheckErr(err)
+		baseDirectory, err := serviceBaseDirectory(client, service, repository)
+		cobra.CheckErr(err)
+
+		var results []localCheckResult
+		var failed bool
+		for _, check := range checks {
+			result, ok := evaluateRepoCheck(root, baseDirectory, check)
+			if !ok {
+				continue
+			}
+			failed = failed || result.Result != "passed"
+			results = append(results, result)
+		}
+		if len(results) == 0 {
+			fmt.Printf("none of the %d checks of '%s' are repository checks that can be evaluated locally\n", len(checks), alias)
+			return
+		}
+		cobra.CheckErr(common.NewPrinter(output,
+			common.NewColumn("RESULT", "result"),
+			common.NewColumn("CHECK", "check"),
+			common.NewColumn("LEVEL", "level"),
+			common.NewColumn("REASON", "reason"),
+			common.NewWideColumn("TYPE", "type"),
+			common.NewWideColumn("CATEGORY", "category"),
+		).PrintList(results))
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(runChecksCmd)
+
+	runChecksCmd.Flags().StringP("service", "s", "", "alias of the service to evaluate the checks of")
+	runChecksCmd.Flags().StringP("repository", "r", "", "alias of the repository checked out at PATH, picks the base directory of the service when it has more than one")
+	runChecksCmd.Flags().StringP("output", "o", "text", "Output format.  One of: "+common.OutputFormats+" [default: text]")
+	runChecksCmd.MarkFlagRequired("service")
+}
+
+type localCheckResult struct {
+	Check    string `json:"check"`
+	Type     string `json:"type"`
+	Category string `json:"category"`
+	Level    string `json:"level"`
+	Result   string `json:"result"`
+	Reason   string `json:"reason"`
+}
+
+// listServiceChecks returns the enabled checks whose filter matches the service, or that have no filter
+func listServiceChecks(client *opslevel.Client, service *opslevel.Service) ([]opslevel.Check, error) {
+	resp, err := client.ListChecks(nil)
+	if err != nil {
+		return nil, err
+	}
+	filterMatches := map[opslevel.ID]bool{}
+	var output []opslevel.Check
+	for _, check := range resp.Nodes {
+		if !check.Enabled {
+			continue
+		}
+		if check.Filter.Id != "" {
+			matches, ok := filterMatches[check.Filter.Id]
+			if !ok {
+				services, err := client.ListServicesWithFilter(string(check.Filter.Id), nil)
+				if err != nil {
+					return nil, err
+				}
+				for _, item := range services.Nodes {
+					matches = matches || item.Id == service.Id
+				}
+				filterMatches[check.Filter.Id] = matches
+			}
+			if !matches {
+				continue
+			}
+		}
+		output = append(output, check)
+	}
+	return output, nil
+}
+
+// serviceBaseDirectory returns the base directory of the service in the repository, or in all of its repositories
+// when no repository is given, and fails when that is ambiguous
+func serviceBaseDirectory(client *opslevel.Client, service *opslevel.Service, repository string) (string, error) {
+	repositories, err := service.GetRepositories(client, nil)
+	if err != nil {
+		return "", err
+	}
+	var directories []string
+	for _, edge := range repositories.Edges {
+		for _, serviceRepository := range edge.ServiceRepositories {
+			if repository != "" && serviceRepository.Repository.DefaultAlias != repository {
+				continue
+			}
+			if !slices.Contains(directories, serviceRepository.BaseDirectory) {
+				directories = append(directories, serviceRepository.BaseDirectory)
+			}
+		}
+	}
+	switch {
+	case repository != "" && len(directories) == 0:
+		return "", fmt.Errorf("service '%s' is not in repository '%s'", service.Name, repository)
+	case len(directories) > 1:
+		return "", fmt.Errorf("service '%s' has the base directories %s in its repositories - use --repository to pick one", service.Name, strings.Join(directories, ", "))
+	case len(directories) == 1:
+		return directories[0], nil
+	}
+	return "", nil
+}
+
+// evaluateRepoCheck runs the check against the working tree and is false for check types that can not be evaluated locally
+func evaluateRepoCheck(checkout string, baseDirectory string, check opslevel.Check) (localCheckResult, bool) {
+	root := common.RepoRoot(checkout, baseDirectory, false)
+	var result common.RepoCheckResult
+	var err error
+	switch check.Type {
+	case opslevel.CheckTypeRepoFile:
+		fragment := check.RepositoryFileCheckFragment
+		var predicate *common.Predicate
+		if fragment.FileContentsPredicate != nil {
+			predicate = toLocalPredicate(*fragment.FileContentsPredicate)
+		}
+		root = common.RepoRoot(checkout, baseDirectory, fragment.UseAbsoluteRoot)
+		result, err = common.EvaluateRepoFile(root, fragment.Filepaths, fragment.DirectorySearch, predicate)
+	case opslevel.CheckTypeRepoGrep:
+		fragment := check.RepositoryGrepCheckFragment
+		result, err = common.EvaluateRepoGrep(root, fragment.Filepaths, fragment.DirectorySearch, *toLocalPredicate(fragment.FileContentsPredicate))
+	case opslevel.CheckTypeRepoSearch:
+		fragment := check.RepositorySearchCheckFragment
+		result, err = common.EvaluateRepoSearch(root, fragment.FileExtensions, *toLocalPredicate(fragment.FileContentsPredicate))
+	default:
+		return localCheckResult{}, false
+	}
+	output := localCheckResult{
+		Check:    check.Name,
+		Type:     string(check.Type),
+		Category: check.Category.Name,
+		Level:    check.Level.Name,
+		Result:   "failed",
+		Reason:   result.Reason,
+	}
+	switch {
+	case err != nil:
+		output.Result = "error"
+		output.Reason = err.Error()
+	case result.Passed:
+		output.Result = "passed"
+	}
+	return output, true
+}
+
+func toLocalPredicate(predicate opslevel.Predicate) *common.Predicate {
+	return &common.Predicate{Type: string(predicate.Type), Value: predicate.Value}
+}
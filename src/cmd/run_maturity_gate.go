@@ -0,0 +1,136 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/opslevel/cli/common"
+	"github.com/opslevel/opslevel-go/v2025"
+	"github.com/spf13/cobra"
+)
+
+var runMaturityGateCmd = &cobra.Command{
+	Use:   "maturity-gate",
+	Short: "Fail when a service is below the required maturity levels",
+	Long: `Fail when a service is below the required maturity levels
+
+The overall level of the service is compared with --min-level and the level of each --category
+with its threshold. Levels are compared by their index in the rubric and can be given by name or alias.
+Exits with status 1 and explains which thresholds were missed when any of them is not met.`,
+	Example: `
+		opslevel run maturity-gate --service cart --min-level Silver
+		opslevel run maturity-gate --service cart --category Security=Gold --category Reliability=Silver --junit maturity.xml
+		`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		alias, err := cmd.Flags().GetString("service")
+		cobra.CheckErr(err)
+		minLevel, err := cmd.Flags().GetString("min-level")
+		cobra.CheckErr(err)
+		categories, err := cmd.Flags().GetStringArray("category")
+		cobra.CheckErr(err)
+		junitPath, err := cmd.Flags().GetString("junit")
+		cobra.CheckErr(err)
+		if minLevel == "" && len(categories) == 0 {
+			cobra.CheckErr(fmt.Errorf("please provide '--min-level' or at least one '--category'"))
+		}
+
+		client := getClientGQL()
+		levels, err := client.ListLevels(nil)
+		cobra.CheckErr(err)
+		maturity, err := client.GetServiceMaturityWithAlias(alias)
+		cobra.CheckErr(err)
+
+		var results []maturityGateResult
+		if minLevel != "" {
+			required, err := findLevel(levels.Nodes, minLevel)
+			cobra.CheckErr(err)
+			results = append(results, compareLevel(alias, "overall level", maturity.MaturityReport.OverallLevel, required, levels.Nodes))
+		}
+		for _, threshold := range categories {
+			name, levelName, ok := strings.Cut(threshold, "=")
+			if !ok {
+				cobra.CheckErr(fmt.Errorf("invalid --category '%s' - must be in the format CATEGORY=LEVEL", threshold))
+			}
+			required, err := findLevel(levels.Nodes, levelName)
+			cobra.CheckErr(err)
+			failure := fmt.Sprintf("service '%s' has no level in category '%s'", alias, name)
+			result := maturityGateResult{
+				JUnitTestCase: common.JUnitTestCase{Name: fmt.Sprintf("category '%s'", name), ClassName: alias, Failure: failure},
+				Summary:       failure,
+			}
+			for _, breakdown := range maturity.MaturityReport.CategoryBreakdown {
+				if strings.EqualFold(breakdown.Category.Name, name) || strings.EqualFold(breakdown.Category.Alias(), name) {
+					result = compareLevel(alias, result.Name, breakdown.Level, required, levels.Nodes)
+					break
+				}
+			}
+			results = append(results, result)
+		}
+
+		var failed int
+		testCases := make([]common.JUnitTestCase, len(results))
+		for i, result := range results {
+			testCases[i] = result.JUnitTestCase
+			if result.Failure != "" {
+				failed++
+				fmt.Printf("FAIL  %s\n", result.Summary)
+			} else {
+				fmt.Printf("PASS  %s\n", result.Summary)
+			}
+		}
+		if junitPath != "" {
+			cobra.CheckErr(common.WriteJUnitFile(junitPath, "opslevel maturity-gate", testCases))
+		}
+		if failed > 0 {
+			fmt.Printf("service '%s' missed %d of %d maturity thresholds\n", alias, failed, len(results))
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	runCmd.AddCommand(runMaturityGateCmd)
+
+	runMaturityGateCmd.Flags().StringP("service", "s", "", "alias of the service to check")
+	runMaturityGateCmd.Flags().String("min-level", "", "the minimum overall level of the service")
+	runMaturityGateCmd.Flags().StringArray("category", nil, "the minimum level of a rubric category as CATEGORY=LEVEL, can be given multiple times")
+	runMaturityGateCmd.Flags().String("junit", "", "write the result of every threshold as a JUnit XML report to this file")
+	runMaturityGateCmd.MarkFlagRequired("service")
+}
+
+// findLevel finds a rubric level by name or alias
+func findLevel(levels []opslevel.Level, key string) (opslevel.Level, error) {
+	var names []string
+	for _, level := range levels {
+		if strings.EqualFold(level.Name, key) || strings.EqualFold(level.Alias, key) {
+			return level, nil
+		}
+		names = append(names, level.Name)
+	}
+	return opslevel.Level{}, fmt.Errorf("level '%s' not found - must be one of: %s", key, strings.Join(names, ", "))
+}
+
+// maturityGateResult is a threshold of the gate, the test case keeps a stable name across runs for CI test reports
+type maturityGateResult struct {
+	common.JUnitTestCase
+	Summary string
+}
+
+// compareLevel compares levels by the index of the rubric level since the level of a maturity report may not include it
+func compareLevel(alias string, name string, actual opslevel.Level, required opslevel.Level, levels []opslevel.Level) maturityGateResult {
+	result := maturityGateResult{
+		JUnitTestCase: common.JUnitTestCase{Name: name, ClassName: alias},
+		Summary:       fmt.Sprintf("%s is %s (requires %s)", name, actual.Name, required.Name),
+	}
+	index := actual.Index
+	if level, err := findLevel(levels, actual.Name); err == nil {
+		index = level.Index
+	}
+	if index < required.Index {
+		result.Failure = fmt.Sprintf("%s of '%s' is %s but %s or higher is required", name, alias, actual.Name, required.Name)
+		result.Summary = result.Failure
+	}
+	return result
+}
@@ -38,7 +38,7 @@ ownerId: "XXX_team_id_XXX"
 affectsOverallServiceLevels: false
 EOF`,
 	Run: func(cmd *cobra.Command, args []string) {
-		input, err := readResourceInput[opslevel.ScorecardInput]()
+		input, err := readOwnedResourceInput[opslevel.ScorecardInput]()
 		cobra.CheckErr(err)
 		result, err := getClientGQL().CreateScorecard(*input)
 		cobra.CheckErr(err)
@@ -57,7 +57,7 @@ var getScorecardCmd = &cobra.Command{
 		key := args[0]
 		scorecard, err := getClientGQL().GetScorecard(key)
 		cobra.CheckErr(err)
-		common.PrettyPrint(scorecard)
+		printResult(scorecard)
 	},
 }
 
@@ -72,16 +72,13 @@ opslevel list scorecards -o json | jq 'map( {(.Name): (.ServiceCount)} )'
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListScorecards(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ID", "NAME", "PASSING_CHECKS", "CHECKS_COUNT", "SERVICE_COUNT")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%d\t%d\t%d\n", item.Id, item.Name, item.PassingChecks, item.TotalChecks, item.ServiceCount)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("ID", "id"),
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("PASSING_CHECKS", "passingChecks"),
+			common.NewColumn("CHECKS_COUNT", "totalChecks"),
+			common.NewColumn("SERVICE_COUNT", "serviceCount"),
+		)
 	},
 }
 
@@ -101,7 +98,7 @@ EOF`,
 	ArgAliases: []string{"ID", "ALIAS"},
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		input, err := readResourceInput[opslevel.ScorecardInput]()
+		input, err := readOwnedResourceInput[opslevel.ScorecardInput]()
 		cobra.CheckErr(err)
 		scorecard, err := getClientGQL().UpdateScorecard(key, *input)
 		cobra.CheckErr(err)
@@ -1,7 +1,6 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
 
 	"github.com/opslevel/opslevel-go/v2025"
@@ -57,11 +56,7 @@ var getSecretCmd = &cobra.Command{
 		cobra.CheckErr(err)
 
 		common.WasFound(result.Id == "", identifier)
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -72,18 +67,13 @@ var listSecretsCmd = &cobra.Command{
 	Long:    `List secrets`,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListSecretsVaultsSecret(nil)
-		list := resp.Nodes
-
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ALIAS", "ID", "OWNER", "UPDATED_AT")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Alias, item.Id, item.Owner.Alias, item.Timestamps.UpdatedAt.Time)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("ALIAS", "alias"),
+			common.NewColumn("ID", "id"),
+			common.NewColumn("OWNER", "owner.alias"),
+			common.NewColumn("UPDATED_AT", "timestamps.updatedAt"),
+		)
 	},
 }
 
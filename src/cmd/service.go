@@ -1,10 +1,11 @@
 package cmd
 
 import (
-	"encoding/csv"
 	"encoding/json"
+	"errors"
 	"fmt"
-	"os"
+	"maps"
+	"slices"
 	"strings"
 
 	"github.com/opslevel/opslevel-go/v2025"
@@ -80,8 +81,8 @@ var getServiceCmd = &cobra.Command{
 		service, err := getService(key)
 		cobra.CheckErr(err)
 
-		// Extra fields only displayed in JSON format
-		if isJsonOutput() {
+		// Extra fields only displayed when printing the whole object
+		if !common.IsTabularOutput(getOutputType) {
 			_, err = service.GetDependents(client, nil)
 			cobra.CheckErr(err)
 			_, err = service.GetDependencies(client, nil)
@@ -91,7 +92,7 @@ var getServiceCmd = &cobra.Command{
 		}
 
 		common.WasFound(service.Id == "", key)
-		common.PrettyPrint(service)
+		printResult(service)
 	},
 }
 
@@ -99,52 +100,157 @@ var listServiceCmd = &cobra.Command{
 	Use:     "service",
 	Aliases: []string{"services", "svc", "svcs"},
 	Short:   "Lists services",
-	Long:    `Lists services`,
+	Long: `Lists services
+
+The filter flags are combined so only services matching all of them are listed.`,
+	Example: `
+		opslevel list service --owner platform --tier tier_1
+		opslevel list service --tag env:prod --tag team:checkout --lifecycle generally_available
+		opslevel list service --system checkout --language go -o json
+		`,
 	Run: func(cmd *cobra.Command, args []string) {
-		list := []opslevel.Service{}
 		client := getClientGQL()
-		resp, err := client.ListServices(nil)
+		list, err := listServicesWithFlags(cmd, client)
 		cobra.CheckErr(err)
-		for _, service := range resp.Nodes {
-			if !isJsonOutput() {
-				list = append(list, service)
-				continue
-			}
-
-			// Extra fields only displayed in JSON format
-			if ok, _ := cmd.Flags().GetBool("dependencies"); ok {
-				_, err = service.GetDependencies(client, nil)
-				cobra.CheckErr(err)
-			}
-			if ok, _ := cmd.Flags().GetBool("dependents"); ok {
-				_, err = service.GetDependents(client, nil)
-				cobra.CheckErr(err)
-			}
-			if ok, _ := cmd.Flags().GetBool("properties"); ok {
-				_, err = service.GetProperties(client, nil)
-				cobra.CheckErr(err)
-			}
-			list = append(list, service)
-		}
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"NAME", "ID", "TYPE", "ALIASES"})
-			for _, item := range list {
-				w.Write([]string{item.Name, string(item.Id), item.Type.Aliases[0], strings.Join(item.Aliases, "/")})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("NAME", "ID", "TYPE", "ALIASES")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t\n", item.Name, item.Id, item.Type.Aliases[0], strings.Join(item.Aliases, ","))
+		if !common.IsTabularOutput(listOutputType) {
+			// Extra fields only displayed when printing whole objects
+			dependencies, _ := cmd.Flags().GetBool("dependencies")
+			dependents, _ := cmd.Flags().GetBool("dependents")
+			properties, _ := cmd.Flags().GetBool("properties")
+			if dependencies || dependents || properties {
+				errs := newExecutor("services").Run(len(list), func(i int) error {
+					service := &list[i]
+					if dependencies {
+						if _, err := service.GetDependencies(client, nil); err != nil {
+							return err
+						}
+					}
+					if dependents {
+						if _, err := service.GetDependents(client, nil); err != nil {
+							return err
+						}
+					}
+					if properties {
+						if _, err := service.GetProperties(client, nil); err != nil {
+							return err
+						}
+					}
+					return nil
+				})
+				cobra.CheckErr(errors.Join(errs...))
 			}
-			w.Flush()
 		}
+		printList(list,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ID", "id"),
+			common.NewColumn("TYPE", "type.aliases.0"),
+			common.NewAliasesColumn("aliases"),
+			common.NewWideColumn("OWNER", "owner.alias"),
+			common.NewWideColumn("TIER", "tier.alias"),
+			common.NewWideColumn("LIFECYCLE", "lifecycle.alias"),
+			common.NewWideColumn("LANGUAGE", "language"),
+			common.NewWideColumn("FRAMEWORK", "framework"),
+		)
 	},
 }
 
+// serviceListFilters are the flags of 'list service' that are filtered by the API
+var serviceListFilters = []struct {
+	flag string
+	list func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error)
+}{
+	{"owner", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithOwner(value, nil)
+	}},
+	{"tier", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithTier(value, nil)
+	}},
+	{"lifecycle", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithLifecycle(value, nil)
+	}},
+	{"language", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithLanguage(value, nil)
+	}},
+	{"framework", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithFramework(value, nil)
+	}},
+	{"product", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithProduct(value, nil)
+	}},
+	{"filter", func(client *opslevel.Client, value string) (*opslevel.ServiceConnection, error) {
+		return client.ListServicesWithFilter(value, nil)
+	}},
+}
+
+// listServicesWithFlags lists the services matching every filter flag given to 'list service'
+func listServicesWithFlags(cmd *cobra.Command, client *opslevel.Client) ([]opslevel.Service, error) {
+	var queries []func() (*opslevel.ServiceConnection, error)
+	for _, filter := range serviceListFilters {
+		value, err := cmd.Flags().GetString(filter.flag)
+		if err != nil {
+			return nil, err
+		}
+		if value == "" {
+			continue
+		}
+		list := filter.list
+		queries = append(queries, func() (*opslevel.ServiceConnection, error) {
+			return list(client, value)
+		})
+	}
+	tags, err := cmd.Flags().GetStringArray("tag")
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range tags {
+		tagArgs, err := opslevel.NewTagArgs(tag)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag '%s' - must be in the format key:value: %w", tag, err)
+		}
+		queries = append(queries, func() (*opslevel.ServiceConnection, error) {
+			return client.ListServicesWithTag(tagArgs, nil)
+		})
+	}
+	if len(queries) == 0 {
+		queries = append(queries, func() (*opslevel.ServiceConnection, error) {
+			return client.ListServices(nil)
+		})
+	}
+
+	var services []opslevel.Service
+	for i, query := range queries {
+		resp, err := query()
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			services = resp.Nodes
+			continue
+		}
+		matched := map[opslevel.ID]bool{}
+		for _, service := range resp.Nodes {
+			matched[service.Id] = true
+		}
+		services = slices.DeleteFunc(services, func(service opslevel.Service) bool {
+			return !matched[service.Id]
+		})
+	}
+
+	system, err := cmd.Flags().GetString("system")
+	if err != nil {
+		return nil, err
+	}
+	if system != "" {
+		services = slices.DeleteFunc(services, func(service opslevel.Service) bool {
+			return service.Parent == nil || (string(service.Parent.Id) != system && !slices.Contains(service.Parent.Aliases, system))
+		})
+	}
+	if services == nil {
+		services = []opslevel.Service{}
+	}
+	return services, nil
+}
+
 var updateServiceCmd = &cobra.Command{
 	Use:     "service",
 	Aliases: []string{"svc"},
@@ -202,64 +308,469 @@ var deleteServiceCmd = &cobra.Command{
 var importServicesCmd = &cobra.Command{
 	Use:     "service",
 	Aliases: []string{"services", "svc", "svcs"},
-	Short:   "Imports services from a CSV",
+	Short:   "Imports services from a CSV, JSON or YAML file",
 	Long: `Imports a list of services from a CSV file with the column headers:
-Name,Description,Product,Language,Framework,Tier,Lifecycle,Owner
+Name,Aliases,Description,Product,Language,Framework,Tier,Lifecycle,Owner,System,Repositories,Tools
+
+Only the Name column is required. Rows are matched to existing services by the values of the
+Aliases column, which can hold multiple comma separated aliases. Matching services are updated
+with the non-empty cells of the row and everything else is created.
+
+The remaining columns are:
+  System        alias or ID of the system the service belongs to ('Parent' is also accepted)
+  Repositories  comma separated repository aliases like 'github.com:org/repo' to attach
+  Tools         comma separated tools as 'category:Display Name=url', category defaults to 'other'
+  tag:KEY       assigns the tag KEY with the cell as the value
+  property:DEF  assigns the property definition DEF with the cell as the value, the cell is
+                always a string for string properties and otherwise read as JSON when it is valid JSON
+
+Unknown tiers, lifecycles, owners, systems, repositories and property definitions fail the row.
+Use --dry-run to print what would happen to each row without making any changes and
+--failed-rows to save the rows that failed so they can be fixed and imported again.
+The import exits 1 when any row failed, also with --dry-run.
+
+JSON, NDJSON and YAML files use the same fields as keys, nested objects such as
+'tag: {env: prod}' are read as the 'tag:env' column and lists are read as comma separated.
 
 Example:
 
 cat << EOF | opslevel import services -f -
-Name,Description,Product,Language,Framework,Tier,Lifecycle,Owner
-Service A,,,Go,Cobra,tier_1,pre_alpha,
-Service B,,,Python,Django,tier_3,beta,sales
-Service C,Test,Home,,,,,platform
+Name,Aliases,Description,Product,Language,Framework,Tier,Lifecycle,Owner,tag:env
+Service A,service_a,,,Go,Cobra,tier_1,pre_alpha,,prod
+Service B,service_b,,,Python,Django,tier_3,beta,sales,
+Service C,,Test,Home,,,,,platform,staging
 EOF
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		reader, err := readImportFilepathAsCSV()
+		dryRun, err := cmd.Flags().GetBool("dry-run")
+		cobra.CheckErr(err)
+		records, report := readImportRecords()
+		report.DryRun = dryRun
+
 		client := getClientGQL()
-		opslevel.Cache.CacheLifecycles(client)
-		opslevel.Cache.CacheTiers(client)
-		opslevel.Cache.CacheTeams(client)
+		lookups, err := newServiceImportLookups(client)
 		cobra.CheckErr(err)
-		for reader.Rows() {
-			name := reader.Text("Name")
-			input := opslevel.ServiceCreateInput{
-				Name:        name,
-				Description: opslevel.RefOf(reader.Text("Description")),
-				Product:     opslevel.RefOf(reader.Text("Product")),
-				Language:    opslevel.RefOf(reader.Text("Language")),
-				Framework:   opslevel.RefOf(reader.Text("Framework")),
-			}
-			tier := reader.Text("Tier")
-			if tier != "" {
-				if item, ok := opslevel.Cache.Tiers[tier]; ok {
-					input.TierAlias = opslevel.RefOf(item.Alias)
+		var valid []common.Record
+		rows := map[int]*serviceImportRow{}
+		for _, record := range records {
+			row, err := lookups.parse(record)
+			if err != nil {
+				err = errors.New(strings.ReplaceAll(err.Error(), "\n", "; "))
+				report.Fail(record, err)
+				if dryRun {
+					fmt.Printf("row %d: error: %s\n", record.Line, err)
+				} else {
+					log.Error().Err(err).Msgf("skipping row %d", record.Line)
 				}
+				continue
 			}
-			lifecycle := reader.Text("Lifecycle")
-			if lifecycle != "" {
-				if item, ok := opslevel.Cache.Lifecycles[lifecycle]; ok {
-					input.LifecycleAlias = opslevel.RefOf(item.Alias)
+			if dryRun {
+				fmt.Printf("row %d: service '%s' would be %s\n", record.Line, row.Name, row.action())
+				if row.Existing != nil {
+					report.Updated++
+				} else {
+					report.Created++
 				}
+				continue
 			}
-			owner := reader.Text("Owner")
-			if owner != "" {
-				if item, ok := opslevel.Cache.Teams[owner]; ok {
-					input.OwnerInput = opslevel.NewIdentifier(item.Alias)
-				}
+			valid = append(valid, record)
+			rows[record.Line] = row
+		}
+		runImport(report, "services", valid, func(record common.Record) (importOutcome, error) {
+			row := rows[record.Line]
+			outcome := importCreated
+			if row.Existing != nil {
+				outcome = importUpdated
 			}
-			service, err := getClientGQL().CreateService(input)
+			service, err := row.apply(client)
 			if err != nil {
-				log.Error().Err(err).Msgf("error creating service '%s'", name)
-				continue
+				return outcome, fmt.Errorf("error importing service '%s': %w", row.Name, err)
 			}
-			log.Info().Msgf("created service '%s' with id '%s'", service.Name, service.Id)
-		}
-		reader.Close()
+			log.Info().Msgf("%s service '%s' with id '%s'", row.action(), service.Name, service.Id)
+			return outcome, nil
+		})
+		report.Finish()
 	},
 }
 
+// serviceImportRow is a validated row of 'import service'
+type serviceImportRow struct {
+	Name         string
+	Aliases      []string
+	Existing     *opslevel.Service
+	Create       opslevel.ServiceCreateInput
+	Tags         map[string]string
+	Properties   map[opslevel.ID]opslevel.JsonString
+	Repositories []string
+	Tools        []opslevel.ToolCreateInput
+
+	// progress of a previous attempt, a retried row resumes after the last call that succeeded
+	service      *opslevel.Service
+	aliases      int
+	tagged       bool
+	properties   int
+	repositories int
+	tools        int
+}
+
+func (row *serviceImportRow) action() string {
+	if row.Existing != nil {
+		return "updated"
+	}
+	return "created"
+}
+
+// apply creates or updates the service then attaches everything else in the row to it
+//
+// apply is retried by the executor so every step records its progress on the row, a retry never
+// creates the service, an alias, a repository or a tool a second time.
+func (row *serviceImportRow) apply(client *opslevel.Client) (*opslevel.Service, error) {
+	if row.service == nil {
+		service, err := row.upsert(client)
+		if err != nil {
+			return nil, err
+		}
+		row.service = service
+	}
+	service := row.service
+	for ; row.aliases < len(row.Aliases); row.aliases++ {
+		if err := ensureAlias(client, service.Id, service.Aliases, row.Aliases[row.aliases]); err != nil {
+			return nil, err
+		}
+	}
+	if len(row.Tags) > 0 && !row.tagged {
+		input := opslevel.TagAssignInput{Id: &service.Id}
+		for _, key := range slices.Sorted(maps.Keys(row.Tags)) {
+			input.Tags = append(input.Tags, opslevel.TagInput{Key: key, Value: row.Tags[key]})
+		}
+		if _, err := client.AssignTag(input); err != nil {
+			return nil, err
+		}
+		row.tagged = true
+	}
+	definitions := slices.Sorted(maps.Keys(row.Properties))
+	for ; row.properties < len(definitions); row.properties++ {
+		definition := definitions[row.properties]
+		_, err := client.PropertyAssign(opslevel.PropertyInput{
+			Owner:      *opslevel.NewIdentifier(string(service.Id)),
+			Definition: *opslevel.NewIdentifier(string(definition)),
+			Value:      row.Properties[definition],
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := row.attachRepositories(client, service); err != nil {
+		return nil, err
+	}
+	return service, row.attachTools(client, service)
+}
+
+func (row *serviceImportRow) upsert(client *opslevel.Client) (*opslevel.Service, error) {
+	if row.Existing == nil {
+		return client.CreateService(row.Create)
+	}
+	return client.UpdateService(opslevel.ServiceUpdateInput{
+		Id:             &row.Existing.Id,
+		Name:           NullableString(nonEmpty(row.Create.Name)),
+		Description:    NullableString(row.Create.Description),
+		Product:        NullableString(row.Create.Product),
+		Language:       NullableString(row.Create.Language),
+		Framework:      NullableString(row.Create.Framework),
+		TierAlias:      NullableString(row.Create.TierAlias),
+		LifecycleAlias: NullableString(row.Create.LifecycleAlias),
+		OwnerInput:     row.Create.OwnerInput,
+		Parent:         row.Create.Parent,
+	})
+}
+
+// attachRepositories skips repositories that are already attached so that imports can be re-run
+func (row *serviceImportRow) attachRepositories(client *opslevel.Client, service *opslevel.Service) error {
+	if row.repositories == len(row.Repositories) {
+		return nil
+	}
+	attached := map[string]bool{}
+	if row.Existing != nil {
+		repositories, err := service.GetRepositories(client, nil)
+		if err != nil {
+			return err
+		}
+		for _, edge := range repositories.Edges {
+			for _, serviceRepository := range edge.ServiceRepositories {
+				attached[serviceRepository.Repository.DefaultAlias] = true
+			}
+		}
+	}
+	for ; row.repositories < len(row.Repositories); row.repositories++ {
+		alias := row.Repositories[row.repositories]
+		if attached[alias] {
+			continue
+		}
+		_, err := client.CreateServiceRepository(opslevel.ServiceRepositoryCreateInput{
+			Service:    *opslevel.NewIdentifier(string(service.Id)),
+			Repository: *opslevel.NewIdentifier(alias),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// attachTools skips tools whose url is already used by the service so that imports can be re-run
+func (row *serviceImportRow) attachTools(client *opslevel.Client, service *opslevel.Service) error {
+	if row.tools == len(row.Tools) {
+		return nil
+	}
+	existing := map[string]bool{}
+	if row.Existing != nil {
+		tools, err := service.GetTools(client, nil)
+		if err != nil {
+			return err
+		}
+		for _, tool := range tools.Nodes {
+			existing[tool.Url] = true
+		}
+	}
+	for ; row.tools < len(row.Tools); row.tools++ {
+		tool := row.Tools[row.tools]
+		if existing[tool.Url] {
+			continue
+		}
+		tool.ServiceId = &service.Id
+		if _, err := client.CreateTool(tool); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// serviceImportLookups are the existing resources that the rows of 'import service' are validated against
+type serviceImportLookups struct {
+	client       *opslevel.Client
+	services     map[string]*opslevel.Service
+	systems      resourceIndex
+	properties   resourceIndex
+	schemas      map[opslevel.ID]any
+	repositories map[string]bool
+}
+
+func newServiceImportLookups(client *opslevel.Client) (*serviceImportLookups, error) {
+	opslevel.Cache.CacheLifecycles(client)
+	opslevel.Cache.CacheTiers(client)
+	opslevel.Cache.CacheTeams(client)
+	resp, err := client.ListServices(nil)
+	if err != nil {
+		return nil, err
+	}
+	services := map[string]*opslevel.Service{}
+	for i, service := range resp.Nodes {
+		for _, alias := range service.Aliases {
+			services[alias] = &resp.Nodes[i]
+		}
+	}
+	systemHandler, _ := getResourceHandler(ResourceKindSystem)
+	systems, err := systemHandler.index(client)
+	if err != nil {
+		return nil, err
+	}
+	propertyHandler, _ := getResourceHandler(ResourceKindPropertyDefinition)
+	definitions, err := propertyHandler.list(client)
+	if err != nil {
+		return nil, err
+	}
+	properties := resourceIndex{}
+	schemas := map[opslevel.ID]any{}
+	for _, definition := range definitions {
+		properties.add(definition.Id, append(slices.Clone(definition.Aliases), definition.Name)...)
+		schemas[definition.Id] = definition.Spec["schema"]
+	}
+	return &serviceImportLookups{
+		client:       client,
+		services:     services,
+		systems:      systems,
+		properties:   properties,
+		schemas:      schemas,
+		repositories: map[string]bool{},
+	}, nil
+}
+
+func (lookups *serviceImportLookups) repositoryExists(alias string) bool {
+	if exists, ok := lookups.repositories[alias]; ok {
+		return exists
+	}
+	repository, err := lookups.client.GetRepositoryWithAlias(alias)
+	exists := err == nil && repository != nil && repository.Id != ""
+	lookups.repositories[alias] = exists
+	return exists
+}
+
+// parse converts the current row to a serviceImportRow, every problem with the row is returned as one error
+func (lookups *serviceImportLookups) parse(record common.Record) (*serviceImportRow, error) {
+	var errs []error
+	row := &serviceImportRow{
+		Name:       record.Text("Name"),
+		Aliases:    append(splitCell(record.Text("Aliases")), splitCell(record.Text("Alias"))...),
+		Tags:       map[string]string{},
+		Properties: map[opslevel.ID]opslevel.JsonString{},
+	}
+	for _, alias := range row.Aliases {
+		if service, ok := lookups.services[alias]; ok {
+			row.Existing = service
+			break
+		}
+	}
+	if row.Name == "" && row.Existing == nil {
+		errs = append(errs, fmt.Errorf("column 'Name' is required for new services"))
+	}
+	row.Create = opslevel.ServiceCreateInput{
+		Name:        row.Name,
+		Description: nonEmpty(record.Text("Description")),
+		Product:     nonEmpty(record.Text("Product")),
+		Language:    nonEmpty(record.Text("Language")),
+		Framework:   nonEmpty(record.Text("Framework")),
+	}
+	if tier := record.Text("Tier"); tier != "" {
+		if item, ok := opslevel.Cache.Tiers[tier]; ok {
+			row.Create.TierAlias = opslevel.RefOf(item.Alias)
+		} else {
+			errs = append(errs, fmt.Errorf("unknown tier '%s'", tier))
+		}
+	}
+	if lifecycle := record.Text("Lifecycle"); lifecycle != "" {
+		if item, ok := opslevel.Cache.Lifecycles[lifecycle]; ok {
+			row.Create.LifecycleAlias = opslevel.RefOf(item.Alias)
+		} else {
+			errs = append(errs, fmt.Errorf("unknown lifecycle '%s'", lifecycle))
+		}
+	}
+	if owner := record.Text("Owner"); owner != "" {
+		if item, ok := opslevel.Cache.Teams[owner]; ok {
+			row.Create.OwnerInput = opslevel.NewIdentifier(item.Alias)
+		} else {
+			errs = append(errs, fmt.Errorf("unknown owner '%s'", owner))
+		}
+	}
+	system := record.Text("System")
+	if system == "" {
+		system = record.Text("Parent")
+	}
+	if system != "" {
+		if id, ok := lookups.systems[system]; ok {
+			row.Create.Parent = opslevel.NewIdentifier(string(id))
+		} else {
+			errs = append(errs, fmt.Errorf("unknown system '%s'", system))
+		}
+	}
+	for _, alias := range splitCell(record.Text("Repositories")) {
+		if lookups.repositoryExists(alias) {
+			row.Repositories = append(row.Repositories, alias)
+		} else {
+			errs = append(errs, fmt.Errorf("unknown repository '%s'", alias))
+		}
+	}
+	for _, item := range splitCell(record.Text("Tools")) {
+		tool, err := parseToolCell(item)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		row.Tools = append(row.Tools, tool)
+	}
+	for _, header := range record.HeaderNames() {
+		value := record.Text(header)
+		if value == "" {
+			continue
+		}
+		if key, ok := cutPrefixFold(header, "tag:"); ok {
+			row.Tags[key] = value
+		}
+		if definition, ok := cutPrefixFold(header, "property:"); ok {
+			if id, exists := lookups.properties[definition]; exists {
+				row.Properties[id] = toPropertyValue(value, lookups.schemas[id])
+			} else {
+				errs = append(errs, fmt.Errorf("unknown property definition '%s'", definition))
+			}
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+// parseToolCell parses 'category:Display Name=url' where the category is optional
+func parseToolCell(value string) (opslevel.ToolCreateInput, error) {
+	name, url, ok := strings.Cut(value, "=")
+	if !ok || url == "" {
+		return opslevel.ToolCreateInput{}, fmt.Errorf("invalid tool '%s' - must be in the format 'category:Display Name=url'", value)
+	}
+	category := opslevel.ToolCategoryOther
+	if prefix, displayName, ok := strings.Cut(name, ":"); ok {
+		if !slices.Contains(opslevel.AllToolCategory, prefix) {
+			return opslevel.ToolCreateInput{}, fmt.Errorf("unknown tool category '%s'", prefix)
+		}
+		category = opslevel.ToolCategory(prefix)
+		name = displayName
+	}
+	return opslevel.ToolCreateInput{
+		Category:    category,
+		DisplayName: strings.TrimSpace(name),
+		Url:         strings.TrimSpace(url),
+	}, nil
+}
+
+// toPropertyValue quotes the cell when the property is a string and otherwise keeps cells that are already JSON,
+// so '123' or 'true' stay strings for string properties
+func toPropertyValue(value string, schema any) opslevel.JsonString {
+	if !isStringSchema(schema) && json.Valid([]byte(value)) {
+		return opslevel.JsonString(value)
+	}
+	data, _ := json.Marshal(value)
+	return opslevel.JsonString(data)
+}
+
+// isStringSchema reports if the JSON schema only allows strings, or strings and null
+func isStringSchema(schema any) bool {
+	fields, ok := schema.(map[string]any)
+	if !ok {
+		return false
+	}
+	switch kind := fields["type"].(type) {
+	case string:
+		return kind == "string"
+	case []any:
+		types := slices.DeleteFunc(slices.Clone(kind), func(item any) bool { return item == "null" })
+		return len(types) > 0 && !slices.ContainsFunc(types, func(item any) bool { return item != "string" })
+	}
+	return false
+}
+
+// splitCell splits a comma separated cell into its non-empty values
+func splitCell(value string) []string {
+	var output []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			output = append(output, item)
+		}
+	}
+	return output
+}
+
+func cutPrefixFold(value, prefix string) (string, bool) {
+	if len(value) <= len(prefix) || !strings.EqualFold(value[:len(prefix)], prefix) {
+		return "", false
+	}
+	return value[len(prefix):], true
+}
+
+func nonEmpty(value string) *string {
+	if value == "" {
+		return nil
+	}
+	return &value
+}
+
 func init() {
 	exampleCmd.AddCommand(exampleServiceCmd)
 	createCmd.AddCommand(createServiceCmd)
@@ -271,8 +782,18 @@ func init() {
 	listServiceCmd.PersistentFlags().Bool("dependencies", false, "Include dependencies of each service")
 	listServiceCmd.PersistentFlags().Bool("dependents", false, "Include dependents of each service")
 	listServiceCmd.PersistentFlags().Bool("properties", false, "Include properties of each service")
+	listServiceCmd.Flags().String("owner", "", "Only list services owned by this team alias")
+	listServiceCmd.Flags().String("tier", "", "Only list services with this tier alias")
+	listServiceCmd.Flags().String("lifecycle", "", "Only list services with this lifecycle alias")
+	listServiceCmd.Flags().StringArray("tag", nil, "Only list services with this tag in `key:value` format, can be repeated")
+	listServiceCmd.Flags().String("language", "", "Only list services written in this language")
+	listServiceCmd.Flags().String("framework", "", "Only list services using this framework")
+	listServiceCmd.Flags().String("product", "", "Only list services that are part of this product")
+	listServiceCmd.Flags().String("system", "", "Only list services in this system, by ID or alias")
+	listServiceCmd.Flags().String("filter", "", "Only list services matching this filter ID")
 
 	importCmd.AddCommand(importServicesCmd)
+	importServicesCmd.Flags().Bool("dry-run", false, "Print what would happen to each row without making any changes")
 }
 
 func NullableString(value *string) *opslevel.Nullable[string] {
@@ -1,11 +1,7 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
-	"strings"
 
 	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
@@ -44,7 +40,7 @@ var createSystemCmd = &cobra.Command{
 		EOF
 		`,
 	Run: func(cmd *cobra.Command, args []string) {
-		input, err := readResourceInput[opslevel.SystemInput]()
+		input, err := readOwnedResourceInput[opslevel.SystemInput]()
 		cobra.CheckErr(err)
 		result, err := getClientGQL().CreateSystem(*input)
 		cobra.CheckErr(err)
@@ -67,11 +63,7 @@ var getSystemCmd = &cobra.Command{
 		result, err := getClientGQL().GetSystem(key)
 		cobra.CheckErr(err)
 		common.WasFound(result.Id == "", key)
-		if isYamlOutput() {
-			common.YamlPrint(result)
-		} else {
-			common.PrettyPrint(result)
-		}
+		printResult(result)
 	},
 }
 
@@ -82,24 +74,13 @@ var listSystemCmd = &cobra.Command{
 	Long:    `Lists the systems`,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListSystems(nil)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"NAME", "ID", "ALIASES"})
-			for _, item := range list {
-				w.Write([]string{item.Name, string(item.Id), strings.Join(item.Aliases, "/")})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Id, strings.Join(item.Aliases, ","))
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ID", "id"),
+			common.NewAliasesColumn("aliases"),
+			common.NewWideColumn("DOMAIN", "parent.name"),
+		)
 	},
 }
 
@@ -122,7 +103,7 @@ var updateSystemCmd = &cobra.Command{
 	ArgAliases: []string{"ID", "ALIAS"},
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
-		input, err := readResourceInput[opslevel.SystemInput]()
+		input, err := readOwnedResourceInput[opslevel.SystemInput]()
 		cobra.CheckErr(err)
 		system, err := getClientGQL().UpdateSystem(key, *input)
 		cobra.CheckErr(err)
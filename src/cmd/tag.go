@@ -113,7 +113,7 @@ opslevel get tag --type=Service my-service-alias foo
 			}
 		}
 
-		common.PrettyPrint(output)
+		printResult(output)
 	},
 }
 
@@ -140,7 +140,11 @@ opslevel list tag --type=Service my-service-alias -o json | jq
 		tags, err := result.GetTags(client, nil)
 		cobra.CheckErr(err)
 
-		common.PrettyPrint(tags.Nodes)
+		printList(tags.Nodes,
+			common.NewColumn("KEY", "key"),
+			common.NewColumn("VALUE", "value"),
+			common.NewColumn("ID", "id"),
+		)
 	},
 }
 
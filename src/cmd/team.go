@@ -1,8 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/opslevel/opslevel-go/v2025"
 	"github.com/rs/zerolog/log"
@@ -182,7 +182,7 @@ var getTeamCmd = &cobra.Command{
 		team, err := GetTeam(key)
 		cobra.CheckErr(err)
 		common.WasFound(team.Id == "", key)
-		common.PrettyPrint(team)
+		printResult(team)
 	},
 }
 
@@ -204,17 +204,14 @@ opslevel list team -o json | jq 'map((.Members.Nodes | map(.Email)))'
 `,
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListTeams(nil)
-		list := resp.Nodes
 		cobra.CheckErr(err)
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("NAME", "ALIAS", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t\n", item.Name, item.Alias, item.Id)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("ALIAS", "alias"),
+			common.NewColumn("ID", "id"),
+			common.NewWideColumn("MANAGER", "manager.email"),
+			common.NewWideColumn("PARENT", "parentTeam.alias"),
+		)
 	},
 }
 
@@ -281,39 +278,81 @@ var deleteContactCmd = &cobra.Command{
 var importTeamsCmd = &cobra.Command{
 	Use:     "team",
 	Aliases: []string{"teams"},
-	Short:   "Imports teams from a CSV",
+	Short:   "Imports teams from a CSV, JSON or YAML file",
 	Long: `Imports a list of teams from a CSV file with the column headers:
-Name,Manager,Responsibilities,ParentTeam`,
+Name,Manager,Responsibilities,ParentTeam
+
+JSON, NDJSON and YAML files use the same fields as keys.`,
 	Example: `
 cat << EOF | opslevel import teams -f -
 Name,Manager,Responsibilities,ParentTeam
 Platform,kyle@opslevel.com,Makes Tools,engineering
 Sales,john@opslevel.com,Sells Tools,product
 EOF
+
+cat << EOF | opslevel import teams -f -
+[{"name": "Platform", "responsibilities": "Makes Tools", "parentTeam": "engineering"}]
+EOF
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		reader, err := readImportFilepathAsCSV()
-		cobra.CheckErr(err)
-		for reader.Rows() {
-			name := reader.Text("Name")
-			input := opslevel.TeamCreateInput{
-				Name:             name,
-				Responsibilities: opslevel.RefOf(reader.Text("Responsibilities")),
-			}
-			parentTeam := reader.Text("ParentTeam")
-			if parentTeam != "" {
-				input.ParentTeam = opslevel.NewIdentifier(parentTeam)
-			}
-			team, err := getClientGQL().CreateTeam(input)
-			if err != nil {
-				log.Error().Err(err).Msgf("error creating team '%s'", name)
-				continue
-			}
-			log.Info().Msgf("created team '%s' with id '%s'", team.Name, team.Id)
+		records, report := readImportRecords()
+		client := getClientGQL()
+		for _, wave := range teamImportWaves(records) {
+			runImport(report, "teams", wave, func(record common.Record) (importOutcome, error) {
+				name := record.Text("Name")
+				input := opslevel.TeamCreateInput{
+					Name:             name,
+					Responsibilities: opslevel.RefOf(record.Text("Responsibilities")),
+				}
+				parentTeam := record.Text("ParentTeam")
+				if parentTeam != "" {
+					input.ParentTeam = opslevel.NewIdentifier(parentTeam)
+				}
+				team, err := client.CreateTeam(input)
+				if err != nil {
+					return importCreated, fmt.Errorf("error creating team '%s': %w", name, err)
+				}
+				log.Info().Msgf("created team '%s' with id '%s'", team.Name, team.Id)
+				return importCreated, nil
+			})
 		}
+		report.Finish()
 	},
 }
 
+// teamImportWaves groups the rows by how many of their ancestors are also rows of the file so that
+// every wave can run concurrently and a parent team is always created before its children
+func teamImportWaves(records []common.Record) [][]common.Record {
+	rows := map[string]int{}
+	for i, record := range records {
+		if name := strings.ToLower(record.Text("Name")); name != "" {
+			rows[name] = i
+			rows[strings.ReplaceAll(name, " ", "_")] = i
+		}
+	}
+	parentOf := func(i int) (int, bool) {
+		parent, ok := rows[strings.ToLower(records[i].Text("ParentTeam"))]
+		return parent, ok
+	}
+	depths := make([]int, len(records))
+	for i := range records {
+		// a cycle of parents is left for the API to reject
+		seen := map[int]bool{i: true}
+		for parent, ok := parentOf(i); ok && !seen[parent]; parent, ok = parentOf(parent) {
+			seen[parent] = true
+			depths[i]++
+		}
+	}
+	var waves [][]common.Record
+	for i, record := range records {
+		for len(waves) <= depths[i] {
+			waves = append(waves, nil)
+		}
+		waves[depths[i]] = append(waves[depths[i]], record)
+	}
+	return waves
+}
+
 func init() {
 	// Team commands
 	exampleCmd.AddCommand(exampleTeamCmd)
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -270,18 +271,23 @@ func exportServices(c *opslevel.Client, shell *os.File, directory string) {
 }
 `
 	resp, err := c.ListServices(nil)
-	services := resp.Nodes
 	cobra.CheckErr(err)
-	for _, service := range services {
+	services := resp.Nodes
+	serviceTags := make([]*opslevel.TagConnection, len(services))
+	errs := newExecutor("service tags").Run(len(services), func(i int) error {
+		tags, err := services[i].GetTags(c, nil)
+		serviceTags[i] = tags
+		return err
+	})
+	cobra.CheckErr(errors.Join(errs...))
+	for i, service := range services {
 		serviceMainAlias := makeTerraformSlug(service.Aliases[0])
 		file := newFile(fmt.Sprintf("%s/opslevel_service_%s.tf", directory, serviceMainAlias), false)
 		aliases := flattenAliases(service.Aliases)
 		if len(aliases) > 0 {
 			aliases = fmt.Sprintf("aliases = [\"%s\"]", aliases)
 		}
-		serviceTags, err := service.GetTags(c, nil)
-		cobra.CheckErr(err)
-		tags := flattenTags(serviceTags.Nodes)
+		tags := flattenTags(serviceTags[i].Nodes)
 		if len(tags) > 0 {
 			tags = fmt.Sprintf("tags = [\"%s\"]", tags)
 		}
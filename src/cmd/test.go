@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+var testCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Invoked to test various OpsLevel configurations locally",
+	Long:  "Invoked to test various OpsLevel configurations locally",
+}
+
+func init() {
+	rootCmd.AddCommand(testCmd)
+}
@@ -77,7 +77,7 @@ var getTriggerDefinitionCmd = &cobra.Command{
 		key := args[0]
 		triggerDefinition, err := getClientGQL().GetTriggerDefinition(key)
 		cobra.CheckErr(err)
-		common.PrettyPrint(triggerDefinition)
+		printResult(triggerDefinition)
 	},
 }
 
@@ -89,16 +89,11 @@ var listTriggerDefinitionCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		resp, err := getClientGQL().ListTriggerDefinitions(nil)
 		cobra.CheckErr(err)
-		list := resp.Nodes
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else {
-			w := common.NewTabWriter("ID", "NAME", "OWNER")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\n", item.Id, item.Name, item.Owner.Alias)
-			}
-			w.Flush()
-		}
+		printList(resp.Nodes,
+			common.NewColumn("ID", "id"),
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("OWNER", "owner.alias"),
+		)
 	},
 }
 
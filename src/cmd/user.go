@@ -1,13 +1,11 @@
 package cmd
 
 import (
-	"encoding/csv"
-	"encoding/json"
 	"fmt"
-	"os"
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/opslevel/cli/common"
 	"github.com/opslevel/opslevel-go/v2025"
@@ -93,9 +91,9 @@ var getUserCmd = &cobra.Command{
 	Args:       cobra.ExactArgs(1),
 	ArgAliases: []string{"ID"},
 	Run: func(cmd *cobra.Command, args []string) {
-		filter, err := getClientGQL().GetUser(args[0])
+		user, err := getClientGQL().GetUser(args[0])
 		cobra.CheckErr(err)
-		common.PrettyPrint(filter)
+		printResult(user)
 	},
 }
 
@@ -126,22 +124,13 @@ opslevel list user -o json | jq 'map({"key": .Name, "value": .Role}) | from_entr
 		sort.Slice(list, func(i, j int) bool {
 			return list[i].Email < list[j].Email
 		})
-		if isJsonOutput() {
-			common.JsonPrint(json.MarshalIndent(list, "", "    "))
-		} else if isCsvOutput() {
-			w := csv.NewWriter(os.Stdout)
-			w.Write([]string{"ID", "EMAIL", "NAME", "ROLE", "URL"})
-			for _, item := range list {
-				w.Write([]string{string(item.Id), item.Email, item.Name, string(item.Role), item.HtmlUrl})
-			}
-			w.Flush()
-		} else {
-			w := common.NewTabWriter("NAME", "EMAIL", "ROLE", "ID")
-			for _, item := range list {
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.Name, item.Email, string(item.Role), item.Id)
-			}
-			w.Flush()
-		}
+		printList(list,
+			common.NewColumn("NAME", "name"),
+			common.NewColumn("EMAIL", "email"),
+			common.NewColumn("ROLE", "role"),
+			common.NewColumn("ID", "id"),
+			common.NewWideColumn("URL", "htmlUrl"),
+		)
 	},
 }
 
@@ -163,9 +152,11 @@ var deleteUserCmd = &cobra.Command{
 var importUsersCmd = &cobra.Command{
 	Use:     "user",
 	Aliases: []string{"users"},
-	Short:   "Imports users from a CSV",
+	Short:   "Imports users from a CSV, JSON or YAML file",
 	Long: `Imports a list of users from a CSV file with the column headers:
-Name,Email,Role,Team`,
+Name,Email,Role,Team
+
+JSON, NDJSON and YAML files use the same fields as keys.`,
 	Example: `
 cat << EOF | opslevel import user --skip-send-invite --skip-welcome-email -f -
 Name,Email,Role,Team
@@ -175,20 +166,22 @@ Adam Del Gobbo,adam@opslevel.com,User,sales
 EOF
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		reader, err := readImportFilepathAsCSV()
-		cobra.CheckErr(err)
 		skipSendInvite, err := cmd.Flags().GetBool("skip-send-invite")
 		cobra.CheckErr(err)
 		skipWelcomeEmail, err := cmd.Flags().GetBool("skip-welcome-email")
 		cobra.CheckErr(err)
 
-		for reader.Rows() {
-			name := reader.Text("Name")
-			email := reader.Text("Email")
-			role := strings.ToLower(reader.Text("Role"))
+		records, report := readImportRecords()
+		client := getClientGQL()
+		// users that were invited by an attempt whose team membership failed, a retry doesn't invite them again
+		var mutex sync.Mutex
+		invited := map[int]*opslevel.User{}
+		runImport(report, "users", records, func(record common.Record) (importOutcome, error) {
+			name := record.Text("Name")
+			email := record.Text("Email")
+			role := strings.ToLower(record.Text("Role"))
 			if email == "" {
-				log.Error().Msgf("user '%s' has invalid email '%s'", name, email)
-				continue
+				return importCreated, fmt.Errorf("user '%s' has invalid email '%s'", name, email)
 			}
 			userRole := opslevel.UserRoleUser
 			if slices.Contains(opslevel.AllUserRole, role) {
@@ -201,32 +194,39 @@ EOF
 				Role:             &userRole,
 				SkipWelcomeEmail: opslevel.RefOf(skipWelcomeEmail),
 			}
-			user, err := getClientGQL().InviteUser(email, input, !skipSendInvite)
-			if err != nil {
-				log.Error().Err(err).Msgf("error inviting user '%s' with email '%s'", name, email)
-				continue
+			mutex.Lock()
+			user := invited[record.Line]
+			mutex.Unlock()
+			if user == nil {
+				var err error
+				user, err = client.InviteUser(email, input, !skipSendInvite)
+				if err != nil {
+					return importCreated, fmt.Errorf("error inviting user '%s' with email '%s': %w", name, email, err)
+				}
+				log.Info().Msgf("invited user '%s' with email '%s'", user.Name, user.Email)
+				mutex.Lock()
+				invited[record.Line] = user
+				mutex.Unlock()
 			}
-			log.Info().Msgf("invited user '%s' with email '%s'", user.Name, user.Email)
-			team := reader.Text("Team")
+			team := record.Text("Team")
 			if team != "" {
 				t, err := GetTeam(team)
 				if err != nil {
-					log.Error().Err(err).Msgf("error finding team '%s' for user '%s'", team, user.Email)
-					continue
+					return importCreated, fmt.Errorf("error finding team '%s' for user '%s': %w", team, user.Email, err)
 				}
 				newMembership := opslevel.TeamMembershipUserInput{
 					User: opslevel.NewUserIdentifier(email),
 					Role: opslevel.RefOf(string(user.Role)),
 				}
-				_, err = getClientGQL().AddMemberships(&t.TeamId, newMembership)
+				_, err = client.AddMemberships(&t.TeamId, newMembership)
 				if err != nil {
-					log.Error().Err(err).Msgf("error adding user '%s' to team '%s'", user.Email, t.Name)
-					continue
+					return importCreated, fmt.Errorf("error adding user '%s' to team '%s': %w", user.Email, t.Name, err)
 				}
 				log.Info().Msgf("added user '%s' to team '%s'", user.Email, t.Name)
 			}
-
-		}
+			return importCreated, nil
+		})
+		report.Finish()
 	},
 }
 
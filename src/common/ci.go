@@ -0,0 +1,175 @@
+package common
+
+import (
+	"fmt"
+	"strings"
+)
+
+// CIEnvironment is the deploy information read from the standard environment variables of a CI provider
+type CIEnvironment struct {
+	Provider      string
+	DeployURL     string
+	DeployNumber  string
+	DeployerName  string
+	DeployerEmail string
+	Branch        string
+	CommitSHA     string
+	Environment   string
+	DedupID       string
+}
+
+type ciProvider struct {
+	name   string
+	detect func(getenv func(string) string) bool
+	read   func(getenv func(string) string) CIEnvironment
+}
+
+var ciProviders = []ciProvider{
+	{
+		name:   "github",
+		detect: func(getenv func(string) string) bool { return getenv("GITHUB_ACTIONS") == "true" },
+		read: func(getenv func(string) string) CIEnvironment {
+			branch := getenv("GITHUB_HEAD_REF")
+			if branch == "" {
+				branch = getenv("GITHUB_REF_NAME")
+			}
+			return CIEnvironment{
+				DeployURL:    joinIfSet("/", getenv("GITHUB_SERVER_URL"), getenv("GITHUB_REPOSITORY"), "actions/runs", getenv("GITHUB_RUN_ID")),
+				DeployNumber: getenv("GITHUB_RUN_NUMBER"),
+				DeployerName: getenv("GITHUB_ACTOR"),
+				Branch:       branch,
+				CommitSHA:    getenv("GITHUB_SHA"),
+				DedupID:      joinIfSet("-", "github", getenv("GITHUB_RUN_ID"), getenv("GITHUB_RUN_ATTEMPT"), getenv("GITHUB_JOB")),
+			}
+		},
+	},
+	{
+		name:   "gitlab",
+		detect: func(getenv func(string) string) bool { return getenv("GITLAB_CI") == "true" },
+		read: func(getenv func(string) string) CIEnvironment {
+			return CIEnvironment{
+				DeployURL:     getenv("CI_PIPELINE_URL"),
+				DeployNumber:  getenv("CI_PIPELINE_IID"),
+				DeployerName:  getenv("GITLAB_USER_NAME"),
+				DeployerEmail: getenv("GITLAB_USER_EMAIL"),
+				Branch:        getenv("CI_COMMIT_REF_NAME"),
+				CommitSHA:     getenv("CI_COMMIT_SHA"),
+				Environment:   getenv("CI_ENVIRONMENT_NAME"),
+				DedupID:       joinIfSet("-", "gitlab", getenv("CI_JOB_ID")),
+			}
+		},
+	},
+	{
+		name:   "circleci",
+		detect: func(getenv func(string) string) bool { return getenv("CIRCLECI") == "true" },
+		read: func(getenv func(string) string) CIEnvironment {
+			return CIEnvironment{
+				DeployURL:    getenv("CIRCLE_BUILD_URL"),
+				DeployNumber: getenv("CIRCLE_BUILD_NUM"),
+				DeployerName: getenv("CIRCLE_USERNAME"),
+				Branch:       getenv("CIRCLE_BRANCH"),
+				CommitSHA:    getenv("CIRCLE_SHA1"),
+				DedupID:      joinIfSet("-", "circleci", getenv("CIRCLE_WORKFLOW_JOB_ID")),
+			}
+		},
+	},
+	{
+		name: "jenkins",
+		detect: func(getenv func(string) string) bool {
+			return getenv("JENKINS_URL") != "" && getenv("BUILD_NUMBER") != ""
+		},
+		read: func(getenv func(string) string) CIEnvironment {
+			branch := getenv("BRANCH_NAME")
+			if branch == "" {
+				branch = strings.TrimPrefix(getenv("GIT_BRANCH"), "origin/")
+			}
+			return CIEnvironment{
+				DeployURL:     getenv("BUILD_URL"),
+				DeployNumber:  getenv("BUILD_NUMBER"),
+				DeployerName:  getenv("BUILD_USER"),
+				DeployerEmail: getenv("BUILD_USER_EMAIL"),
+				Branch:        branch,
+				CommitSHA:     getenv("GIT_COMMIT"),
+				DedupID:       getenv("BUILD_TAG"),
+			}
+		},
+	},
+	{
+		name:   "buildkite",
+		detect: func(getenv func(string) string) bool { return getenv("BUILDKITE") == "true" },
+		read: func(getenv func(string) string) CIEnvironment {
+			return CIEnvironment{
+				DeployURL:     getenv("BUILDKITE_BUILD_URL"),
+				DeployNumber:  getenv("BUILDKITE_BUILD_NUMBER"),
+				DeployerName:  getenv("BUILDKITE_BUILD_CREATOR"),
+				DeployerEmail: getenv("BUILDKITE_BUILD_CREATOR_EMAIL"),
+				Branch:        getenv("BUILDKITE_BRANCH"),
+				CommitSHA:     getenv("BUILDKITE_COMMIT"),
+				DedupID:       joinIfSet("-", "buildkite", getenv("BUILDKITE_JOB_ID")),
+			}
+		},
+	},
+	{
+		name:   "bitbucket",
+		detect: func(getenv func(string) string) bool { return getenv("BITBUCKET_BUILD_NUMBER") != "" },
+		read: func(getenv func(string) string) CIEnvironment {
+			return CIEnvironment{
+				DeployURL:    joinIfSet("/", "https://bitbucket.org", getenv("BITBUCKET_REPO_FULL_NAME"), "pipelines/results", getenv("BITBUCKET_BUILD_NUMBER")),
+				DeployNumber: getenv("BITBUCKET_BUILD_NUMBER"),
+				Branch:       getenv("BITBUCKET_BRANCH"),
+				CommitSHA:    getenv("BITBUCKET_COMMIT"),
+				Environment:  getenv("BITBUCKET_DEPLOYMENT_ENVIRONMENT"),
+				DedupID:      joinIfSet("-", "bitbucket", strings.Trim(getenv("BITBUCKET_STEP_UUID"), "{}")),
+			}
+		},
+	},
+	{
+		name:   "azure",
+		detect: func(getenv func(string) string) bool { return strings.EqualFold(getenv("TF_BUILD"), "true") },
+		read: func(getenv func(string) string) CIEnvironment {
+			var url string
+			if collection, project, id := getenv("SYSTEM_COLLECTIONURI"), getenv("SYSTEM_TEAMPROJECT"), getenv("BUILD_BUILDID"); collection != "" && project != "" && id != "" {
+				url = fmt.Sprintf("%s/%s/_build/results?buildId=%s", strings.TrimSuffix(collection, "/"), project, id)
+			}
+			branch := getenv("SYSTEM_PULLREQUEST_SOURCEBRANCH")
+			if branch == "" {
+				branch = getenv("BUILD_SOURCEBRANCH")
+			}
+			return CIEnvironment{
+				DeployURL:     url,
+				DeployNumber:  getenv("BUILD_BUILDNUMBER"),
+				DeployerName:  getenv("BUILD_REQUESTEDFOR"),
+				DeployerEmail: getenv("BUILD_REQUESTEDFOREMAIL"),
+				Branch:        strings.TrimPrefix(branch, "refs/heads/"),
+				CommitSHA:     getenv("BUILD_SOURCEVERSION"),
+				Environment:   getenv("ENVIRONMENT_NAME"),
+				DedupID:       joinIfSet("-", "azure", getenv("BUILD_BUILDID"), getenv("SYSTEM_JOBID")),
+			}
+		},
+	},
+}
+
+// DetectCI returns the deploy information of the CI provider the command is running in
+//
+// Supported providers are GitHub Actions, GitLab CI, CircleCI, Jenkins, Buildkite,
+// Bitbucket Pipelines and Azure DevOps. ok is false when no provider is detected.
+func DetectCI(getenv func(string) string) (CIEnvironment, bool) {
+	for _, provider := range ciProviders {
+		if provider.detect(getenv) {
+			output := provider.read(getenv)
+			output.Provider = provider.name
+			return output, true
+		}
+	}
+	return CIEnvironment{}, false
+}
+
+// joinIfSet joins the values with the separator only when every value is set
+func joinIfSet(separator string, values ...string) string {
+	for _, value := range values {
+		if value == "" {
+			return ""
+		}
+	}
+	return strings.Join(values, separator)
+}
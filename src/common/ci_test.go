@@ -0,0 +1,119 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func getenvFrom(values map[string]string) func(string) string {
+	return func(key string) string {
+		return values[key]
+	}
+}
+
+func TestDetectCIGitHub(t *testing.T) {
+	// Arrange
+	getenv := getenvFrom(map[string]string{
+		"GITHUB_ACTIONS":     "true",
+		"GITHUB_SERVER_URL":  "https://github.com",
+		"GITHUB_REPOSITORY":  "OpsLevel/cli",
+		"GITHUB_RUN_ID":      "1234",
+		"GITHUB_RUN_ATTEMPT": "2",
+		"GITHUB_JOB":         "deploy",
+		"GITHUB_RUN_NUMBER":  "56",
+		"GITHUB_ACTOR":       "octocat",
+		"GITHUB_REF_NAME":    "main",
+		"GITHUB_SHA":         "abc123",
+	})
+	// Act
+	result, ok := common.DetectCI(getenv)
+	// Assert
+	autopilot.Assert(t, ok, "expected github to be detected")
+	autopilot.Equals(t, common.CIEnvironment{
+		Provider:     "github",
+		DeployURL:    "https://github.com/OpsLevel/cli/actions/runs/1234",
+		DeployNumber: "56",
+		DeployerName: "octocat",
+		Branch:       "main",
+		CommitSHA:    "abc123",
+		DedupID:      "github-1234-2-deploy",
+	}, result)
+}
+
+func TestDetectCIGitLab(t *testing.T) {
+	// Arrange
+	getenv := getenvFrom(map[string]string{
+		"GITLAB_CI":           "true",
+		"CI_PIPELINE_URL":     "https://gitlab.com/opslevel/cli/-/pipelines/9",
+		"CI_PIPELINE_IID":     "9",
+		"GITLAB_USER_NAME":    "Kyle",
+		"GITLAB_USER_EMAIL":   "kyle@opslevel.com",
+		"CI_COMMIT_REF_NAME":  "release",
+		"CI_ENVIRONMENT_NAME": "production",
+		"CI_JOB_ID":           "77",
+	})
+	// Act
+	result, ok := common.DetectCI(getenv)
+	// Assert
+	autopilot.Assert(t, ok, "expected gitlab to be detected")
+	autopilot.Equals(t, "gitlab", result.Provider)
+	autopilot.Equals(t, "kyle@opslevel.com", result.DeployerEmail)
+	autopilot.Equals(t, "release", result.Branch)
+	autopilot.Equals(t, "production", result.Environment)
+	autopilot.Equals(t, "gitlab-77", result.DedupID)
+}
+
+func TestDetectCIJenkinsBranch(t *testing.T) {
+	// Arrange
+	getenv := getenvFrom(map[string]string{
+		"JENKINS_URL":  "https://jenkins.example.com/",
+		"BUILD_NUMBER": "12",
+		"GIT_BRANCH":   "origin/feature",
+		"BUILD_TAG":    "jenkins-deploy-12",
+	})
+	// Act
+	result, ok := common.DetectCI(getenv)
+	// Assert
+	autopilot.Assert(t, ok, "expected jenkins to be detected")
+	autopilot.Equals(t, "feature", result.Branch)
+	autopilot.Equals(t, "jenkins-deploy-12", result.DedupID)
+}
+
+func TestDetectCIAzure(t *testing.T) {
+	// Arrange
+	getenv := getenvFrom(map[string]string{
+		"TF_BUILD":             "True",
+		"SYSTEM_COLLECTIONURI": "https://dev.azure.com/opslevel/",
+		"SYSTEM_TEAMPROJECT":   "cli",
+		"BUILD_BUILDID":        "42",
+		"BUILD_SOURCEBRANCH":   "refs/heads/main",
+		"SYSTEM_JOBID":         "job",
+	})
+	// Act
+	result, ok := common.DetectCI(getenv)
+	// Assert
+	autopilot.Assert(t, ok, "expected azure to be detected")
+	autopilot.Equals(t, "https://dev.azure.com/opslevel/cli/_build/results?buildId=42", result.DeployURL)
+	autopilot.Equals(t, "main", result.Branch)
+	autopilot.Equals(t, "azure-42-job", result.DedupID)
+}
+
+func TestDetectCIMissingValues(t *testing.T) {
+	// Arrange
+	getenv := getenvFrom(map[string]string{"BUILDKITE": "true"})
+	// Act
+	result, ok := common.DetectCI(getenv)
+	// Assert
+	autopilot.Assert(t, ok, "expected buildkite to be detected")
+	autopilot.Equals(t, common.CIEnvironment{Provider: "buildkite"}, result)
+}
+
+func TestDetectCINone(t *testing.T) {
+	// Arrange
+	// Act
+	_, ok := common.DetectCI(getenvFrom(map[string]string{}))
+	// Assert
+	autopilot.Equals(t, false, ok)
+}
@@ -11,17 +11,19 @@ import (
 
 func NewGraphClient(version string, options ...opslevel.Option) *opslevel.Client {
 	timeout := time.Second * time.Duration(viper.GetInt("api-timeout"))
+	url := viper.GetString("api-url")
 	options = append(
 		options,
 		opslevel.SetAPIToken(viper.GetString("api-token")),
-		opslevel.SetURL(viper.GetString("api-url")),
+		opslevel.SetURL(url),
 		opslevel.SetTimeout(timeout),
 		opslevel.SetUserAgentExtra(fmt.Sprintf("cli-%s", version)),
 	)
 	client := opslevel.NewGQLClient(options...)
 
-	clientErr := client.Validate()
-	cobra.CheckErr(clientErr)
+	if err := client.Validate(); err != nil {
+		cobra.CheckErr(fmt.Errorf("unable to authenticate with the OpsLevel API at '%s' - check that the API token is valid for this account or run 'opslevel auth login': %w", url, err))
+	}
 
 	return client
 }
@@ -0,0 +1,200 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultProfile is the profile used when none is selected
+const DefaultProfile = "default"
+
+// Profile is a named set of settings for one OpsLevel account
+//
+// The keys are the same as the flags they set so 'api-url' in a profile is the same as --api-url.
+type Profile struct {
+	ApiUrl          string `yaml:"api-url,omitempty"`
+	ApiToken        string `yaml:"api-token,omitempty"`
+	ApiTokenCommand string `yaml:"api-token-command,omitempty"`
+	ApiTimeout      int    `yaml:"api-timeout,omitempty"`
+	Output          string `yaml:"output,omitempty"`
+	GithubUrl       string `yaml:"github-url,omitempty"`
+	GitlabUrl       string `yaml:"gitlab-url,omitempty"`
+	BitbucketUrl    string `yaml:"bitbucket-url,omitempty"`
+	AzureDevopsUrl  string `yaml:"azure-devops-url,omitempty"`
+}
+
+// Config is the config file that holds the profiles
+//
+//	current-profile: sandbox
+//	profiles:
+//	  sandbox:
+//	    api-url: https://app.opslevel.com
+//	    api-token-command: op read op://opslevel/sandbox/token
+//	  production:
+//	    api-token: XXX
+//	    output: json
+type Config struct {
+	CurrentProfile string             `yaml:"current-profile,omitempty"`
+	Profiles       map[string]Profile `yaml:"profiles,omitempty"`
+}
+
+// ConfigPath is '$XDG_CONFIG_HOME/opslevel/config.yaml' falling back to '~/.config/opslevel/config.yaml'
+func ConfigPath() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "opslevel", "config.yaml"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "opslevel", "config.yaml"), nil
+}
+
+// ReadConfig reads the config file, a file that doesn't exist is an empty config
+func ReadConfig(path string) (*Config, error) {
+	output := &Config{Profiles: map[string]Profile{}}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return output, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, output); err != nil {
+		return nil, fmt.Errorf("unable to read config file '%s': %w", path, err)
+	}
+	if output.Profiles == nil {
+		output.Profiles = map[string]Profile{}
+	}
+	return output, nil
+}
+
+// Write saves the config file readable only by the current user since profiles can hold tokens
+func (c *Config) Write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(path, 0o600)
+}
+
+// ProfileName returns the name if set, otherwise the current profile or else the default profile
+func (c *Config) ProfileName(name string) string {
+	if name != "" {
+		return name
+	}
+	if c.CurrentProfile != "" {
+		return c.CurrentProfile
+	}
+	return DefaultProfile
+}
+
+// Profile returns the named profile, see ProfileName for which profile an empty name selects
+func (c *Config) Profile(name string) (Profile, bool) {
+	profile, ok := c.Profiles[c.ProfileName(name)]
+	return profile, ok
+}
+
+// ProfileNames returns the names of every profile sorted alphabetically
+func (c *Config) ProfileNames() []string {
+	var output []string
+	for name := range c.Profiles {
+		output = append(output, name)
+	}
+	slices.Sort(output)
+	return output
+}
+
+// Redacted returns a copy of the config with the tokens hidden so it is safe to print
+func (c *Config) Redacted() *Config {
+	output := &Config{CurrentProfile: c.CurrentProfile, Profiles: map[string]Profile{}}
+	for name, profile := range c.Profiles {
+		if profile.ApiToken != "" {
+			profile.ApiToken = "REDACTED"
+		}
+		output.Profiles[name] = profile
+	}
+	return output
+}
+
+// Credentials are the API tokens saved by 'opslevel auth login' keyed by profile name
+type Credentials map[string]string
+
+// CredentialsPath is the 'credentials.yaml' file next to the config file
+func CredentialsPath() (string, error) {
+	path, err := ConfigPath()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(filepath.Dir(path), "credentials.yaml"), nil
+}
+
+// ReadCredentials reads the credentials file, a file that doesn't exist has no credentials
+func ReadCredentials(path string) (Credentials, error) {
+	output := Credentials{}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return output, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &output); err != nil {
+		return nil, fmt.Errorf("unable to read credentials file '%s': %w", path, err)
+	}
+	if output == nil {
+		output = Credentials{}
+	}
+	return output, nil
+}
+
+// Write saves the credentials file readable only by the current user
+func (c Credentials) Write(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return err
+	}
+	// WriteFile keeps the permissions of an existing file
+	return os.Chmod(path, 0o600)
+}
+
+// RunTokenCommand runs the command with the shell and returns its output as the API token
+func RunTokenCommand(command string) (string, error) {
+	var cmd *exec.Cmd
+	if runtime.GOOS == "windows" {
+		cmd = exec.Command("cmd", "/C", command)
+	} else {
+		cmd = exec.Command("sh", "-c", command)
+	}
+	cmd.Stderr = os.Stderr
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("api token command '%s' failed: %w", command, err)
+	}
+	token := strings.TrimSpace(string(output))
+	if token == "" {
+		return "", fmt.Errorf("api token command '%s' did not output a token", command)
+	}
+	return token, nil
+}
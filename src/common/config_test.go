@@ -0,0 +1,83 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestReadConfigMissingFile(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	// Act
+	config, err := common.ReadConfig(path)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, common.DefaultProfile, config.ProfileName(""))
+	autopilot.Equals(t, 0, len(config.ProfileNames()))
+}
+
+func TestConfigWriteAndRead(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "opslevel", "config.yaml")
+	config := &common.Config{
+		CurrentProfile: "sandbox",
+		Profiles: map[string]common.Profile{
+			"sandbox":    {ApiUrl: "https://sandbox.opslevel.com", ApiToken: "secret"},
+			"production": {ApiTokenCommand: "echo token", Output: "json"},
+		},
+	}
+	autopilot.Ok(t, os.MkdirAll(filepath.Dir(path), 0o700))
+	autopilot.Ok(t, os.WriteFile(path, []byte("profiles: {}\n"), 0o644))
+	// Act
+	autopilot.Ok(t, config.Write(path))
+	result, err := common.ReadConfig(path)
+	autopilot.Ok(t, err)
+	profile, ok := result.Profile("")
+	// Assert
+	autopilot.Assert(t, ok, "expected the current profile to exist")
+	autopilot.Equals(t, "https://sandbox.opslevel.com", profile.ApiUrl)
+	autopilot.Equals(t, []string{"production", "sandbox"}, result.ProfileNames())
+	autopilot.Equals(t, "REDACTED", result.Redacted().Profiles["sandbox"].ApiToken)
+	autopilot.Equals(t, "secret", result.Profiles["sandbox"].ApiToken)
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
+
+func TestRunTokenCommand(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("uses sh")
+	}
+	// Arrange
+	// Act
+	token, err := common.RunTokenCommand("echo '  my-token  '")
+	_, emptyErr := common.RunTokenCommand("true")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, "my-token", token)
+	autopilot.Assert(t, emptyErr != nil, "expected an error when the command outputs nothing")
+}
+
+func TestCredentialsWriteAndRead(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "opslevel", "credentials.yaml")
+	credentials := common.Credentials{"default": "token-a", "sandbox": "token-b"}
+	// Act
+	autopilot.Ok(t, credentials.Write(path))
+	result, err := common.ReadCredentials(path)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, credentials, result)
+	if runtime.GOOS != "windows" {
+		info, err := os.Stat(path)
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, os.FileMode(0o600), info.Mode().Perm())
+	}
+}
@@ -3,8 +3,10 @@ package common
 import (
 	"encoding/csv"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 )
 
 // Implementation inspired from - https://stackoverflow.com/questions/24999079/reading-csv-file-in-go
@@ -22,8 +24,47 @@ func (s *CSVReader) Rows() bool {
 	return err == nil
 }
 
+// Text returns the cell of the current row in the header's column or an empty string when there is no such column
+//
+// Headers are matched exactly first and then case-insensitively.
 func (s *CSVReader) Text(header string) string {
-	return s.Row[s.Headers[header]]
+	index, ok := s.Headers[header]
+	if !ok {
+		for key, i := range s.Headers {
+			if strings.EqualFold(key, header) {
+				index, ok = i, true
+				break
+			}
+		}
+	}
+	if !ok || index >= len(s.Row) {
+		return ""
+	}
+	return s.Row[index]
+}
+
+// HeaderNames returns the headers in the order of their columns
+func (s *CSVReader) HeaderNames() []string {
+	output := make([]string, len(s.Headers))
+	for header, index := range s.Headers {
+		output[index] = header
+	}
+	return output
+}
+
+// Values returns the cells of the current row
+func (s *CSVReader) Values() []string {
+	return s.Row
+}
+
+// IsBlank is true when every cell of the current row is empty
+func (s *CSVReader) IsBlank() bool {
+	for _, cell := range s.Row {
+		if strings.TrimSpace(cell) != "" {
+			return false
+		}
+	}
+	return true
 }
 
 func (s *CSVReader) Bool(header string) bool {
@@ -35,6 +76,9 @@ func (s *CSVReader) Bool(header string) bool {
 }
 
 func (s *CSVReader) Close() error {
+	if s.fileReader == nil {
+		return nil
+	}
 	return s.fileReader.Close()
 }
 
@@ -43,9 +87,13 @@ func ReadCSVFile(filePath string) (*CSVReader, error) {
 	if err != nil {
 		return nil, fmt.Errorf("unable to read file '%s' : %s", filePath, err)
 	}
-	reader := csv.NewReader(fileReader)
-	records, err2 := reader.Read()
-	if err2 != nil {
+	return newCSVReader(fileReader, fileReader, filePath)
+}
+
+func newCSVReader(fileReader *os.File, data io.Reader, filePath string) (*CSVReader, error) {
+	reader := csv.NewReader(data)
+	records, err := reader.Read()
+	if err != nil {
 		return nil, fmt.Errorf("failed reading file '%s' : %s", filePath, err)
 	}
 	headers := map[string]int{}
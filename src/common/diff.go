@@ -0,0 +1,55 @@
+package common
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/hexops/gotextdiff"
+	"github.com/hexops/gotextdiff/myers"
+	"github.com/hexops/gotextdiff/span"
+	"github.com/mattn/go-isatty"
+)
+
+const (
+	colorReset = "\033[0m"
+	colorRed   = "\033[31m"
+	colorGreen = "\033[32m"
+	colorCyan  = "\033[36m"
+)
+
+// UnifiedDiff returns a unified diff between two texts or an empty string when they are the same
+func UnifiedDiff(fromName, toName, from, to string) string {
+	if from == to {
+		return ""
+	}
+	edits := myers.ComputeEdits(span.URIFromPath(fromName), from, to)
+	return fmt.Sprint(gotextdiff.ToUnified(fromName, toName, from, edits))
+}
+
+// ColorizeDiff adds ANSI colors to the lines of a unified diff
+func ColorizeDiff(diff string) string {
+	lines := strings.SplitAfter(diff, "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"), strings.HasPrefix(line, "@@"):
+			lines[i] = colorize(colorCyan, line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = colorize(colorRed, line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = colorize(colorGreen, line)
+		}
+	}
+	return strings.Join(lines, "")
+}
+
+func colorize(color, line string) string {
+	text := strings.TrimSuffix(line, "\n")
+	return color + text + colorReset + line[len(text):]
+}
+
+// IsTerminal reports if stdout is attached to a terminal
+func IsTerminal() bool {
+	fd := os.Stdout.Fd()
+	return isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd)
+}
@@ -0,0 +1,41 @@
+package common_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+
+	"github.com/rocktavious/autopilot"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	// Arrange
+	from := "name: Shopping Cart\ntier: tier_2\n"
+	to := "name: Shopping Cart\ntier: tier_1\n"
+	// Act
+	diff := common.UnifiedDiff("live", "manifest", from, to)
+	// Assert
+	autopilot.Equals(t, true, strings.Contains(diff, "--- live\n"))
+	autopilot.Equals(t, true, strings.Contains(diff, "+++ manifest\n"))
+	autopilot.Equals(t, true, strings.Contains(diff, "\n name: Shopping Cart\n"))
+	autopilot.Equals(t, true, strings.Contains(diff, "\n-tier: tier_2\n"))
+	autopilot.Equals(t, true, strings.Contains(diff, "\n+tier: tier_1\n"))
+}
+
+func TestUnifiedDiffNoChanges(t *testing.T) {
+	// Arrange
+	// Act
+	diff := common.UnifiedDiff("live", "manifest", "name: a\n", "name: a\n")
+	// Assert
+	autopilot.Equals(t, "", diff)
+}
+
+func TestColorizeDiff(t *testing.T) {
+	// Arrange
+	diff := "--- a\n+++ b\n@@ -1 +1 @@\n-old\n+new\n same\n"
+	// Act
+	colored := common.ColorizeDiff(diff)
+	// Assert
+	autopilot.Equals(t, "\033[36m--- a\033[0m\n\033[36m+++ b\033[0m\n\033[36m@@ -1 +1 @@\033[0m\n\033[31m-old\033[0m\n\033[32m+new\033[0m\n same\n", colored)
+}
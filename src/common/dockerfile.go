@@ -0,0 +1,100 @@
+package common
+
+import (
+	"encoding/json"
+	"regexp"
+	"strings"
+)
+
+// DockerfileInstruction is a single instruction of a Dockerfile
+//
+// Cmd is the lowercase instruction, Flags are its leading '--' options and Value is the list of arguments
+// of the exec form or the words of the arguments. RUN, CMD, ENTRYPOINT and SHELL in shell form keep
+// the whole command as a single value. Stage is the index of the FROM the instruction belongs to.
+type DockerfileInstruction struct {
+	Cmd      string   `json:"cmd"`
+	Flags    []string `json:"flags"`
+	Value    []string `json:"value"`
+	Stage    int      `json:"stage"`
+	Line     int      `json:"line"`
+	Original string   `json:"original"`
+}
+
+var dockerfileHeredoc = regexp.MustCompile(`<<-?["']?([A-Za-z_][A-Za-z0-9_]*)["']?`)
+
+// ParseDockerfile splits a Dockerfile into instructions, joining continued lines and heredocs
+func ParseDockerfile(data []byte) []DockerfileInstruction {
+	output := []DockerfileInstruction{}
+	lines := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	stage := -1
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimSpace(lines[i])
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		start := i + 1
+		original := []string{line}
+		for strings.HasSuffix(line, "\\") && i+1 < len(lines) {
+			i++
+			next := strings.TrimSpace(lines[i])
+			if next == "" || strings.HasPrefix(next, "#") {
+				continue
+			}
+			line = strings.TrimSuffix(line, "\\") + next
+			original = append(original, next)
+		}
+		line = strings.TrimSuffix(line, "\\")
+		var heredocs []string
+		for _, match := range dockerfileHeredoc.FindAllStringSubmatch(line, -1) {
+			for i+1 < len(lines) {
+				i++
+				original = append(original, lines[i])
+				if strings.TrimSpace(lines[i]) == match[1] {
+					break
+				}
+				heredocs = append(heredocs, lines[i])
+			}
+		}
+
+		cmd, args, _ := strings.Cut(line, " ")
+		instruction := DockerfileInstruction{
+			Cmd:      strings.ToLower(cmd),
+			Flags:    []string{},
+			Line:     start,
+			Original: strings.Join(original, "\n"),
+		}
+		args = strings.TrimSpace(args)
+		for strings.HasPrefix(args, "--") {
+			flag, rest, _ := strings.Cut(args, " ")
+			instruction.Flags = append(instruction.Flags, flag)
+			args = strings.TrimSpace(rest)
+		}
+		if instruction.Cmd == "from" {
+			stage++
+		}
+		instruction.Stage = max(stage, 0)
+		instruction.Value = dockerfileValue(instruction.Cmd, args)
+		if len(heredocs) > 0 {
+			instruction.Value = append(instruction.Value, strings.Join(heredocs, "\n"))
+		}
+		output = append(output, instruction)
+	}
+	return output
+}
+
+func dockerfileValue(cmd string, args string) []string {
+	if strings.HasPrefix(args, "[") {
+		var exec []string
+		if err := json.Unmarshal([]byte(args), &exec); err == nil {
+			return exec
+		}
+	}
+	switch cmd {
+	case "run", "cmd", "entrypoint", "shell":
+		if args == "" {
+			return []string{}
+		}
+		return []string{args}
+	}
+	return append([]string{}, strings.Fields(args)...)
+}
@@ -0,0 +1,39 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestParseDockerfile(t *testing.T) {
+	// Arrange
+	data := []byte(`# syntax=docker/dockerfile:1
+FROM golang:1.24 AS build
+ENV CGO_ENABLED=0 GOOS=linux
+RUN apt-get update && \
+    # refresh the certificates
+    apt-get install -y ca-certificates
+COPY --from=build --chmod=755 /src/app /app
+RUN <<EOF
+echo hello
+EOF
+
+FROM alpine:3
+ENTRYPOINT ["/app", "--serve"]
+`)
+	// Act
+	result := common.ParseDockerfile(data)
+	// Assert
+	autopilot.Equals(t, 7, len(result))
+	autopilot.Equals(t, common.DockerfileInstruction{Cmd: "from", Flags: []string{}, Value: []string{"golang:1.24", "AS", "build"}, Stage: 0, Line: 2, Original: "FROM golang:1.24 AS build"}, result[0])
+	autopilot.Equals(t, []string{"CGO_ENABLED=0", "GOOS=linux"}, result[1].Value)
+	autopilot.Equals(t, []string{"apt-get update && apt-get install -y ca-certificates"}, result[2].Value)
+	autopilot.Equals(t, 4, result[2].Line)
+	autopilot.Equals(t, []string{"--from=build", "--chmod=755"}, result[3].Flags)
+	autopilot.Equals(t, []string{"/src/app", "/app"}, result[3].Value)
+	autopilot.Equals(t, []string{"<<EOF", "echo hello"}, result[4].Value)
+	autopilot.Equals(t, 1, result[5].Stage)
+	autopilot.Equals(t, common.DockerfileInstruction{Cmd: "entrypoint", Flags: []string{}, Value: []string{"/app", "--serve"}, Stage: 1, Line: 13, Original: `ENTRYPOINT ["/app", "--serve"]`}, result[6])
+}
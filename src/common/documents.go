@@ -0,0 +1,144 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a single object read from a JSON or YAML stream with the line it starts on
+type Document struct {
+	Line int
+	raw  json.RawMessage
+	node *yaml.Node
+}
+
+// Decode decodes the document into value using its json tags for JSON and yaml tags for YAML
+func (d Document) Decode(value any) error {
+	if d.node != nil {
+		return d.node.Decode(value)
+	}
+	return json.Unmarshal(d.raw, value)
+}
+
+// ReadDocuments splits NDJSON, a JSON list, YAML documents or a YAML list into its objects
+//
+// The format is one of the RecordFormats except csv.
+func ReadDocuments(data []byte, format string) ([]Document, error) {
+	switch strings.ToLower(format) {
+	case "json", "ndjson", "jsonl":
+		return readJsonDocuments(data)
+	case "yaml", "yml":
+		return readYamlDocuments(data)
+	default:
+		return nil, fmt.Errorf("unknown format '%s' - must be one of: json|ndjson|yaml", format)
+	}
+}
+
+func readJsonDocuments(data []byte) ([]Document, error) {
+	var output []Document
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	lineAt := func(offset int64) int {
+		start := int(offset)
+		for start < len(data) && strings.ContainsRune(" \t\r\n,", rune(data[start])) {
+			start++
+		}
+		return bytes.Count(data[:start], []byte("\n")) + 1
+	}
+	for {
+		line := lineAt(decoder.InputOffset())
+		if bytes.HasPrefix(bytes.TrimLeft(data[decoder.InputOffset():], " \t\r\n"), []byte("[")) {
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			for decoder.More() {
+				line := lineAt(decoder.InputOffset())
+				var raw json.RawMessage
+				if err := decoder.Decode(&raw); err != nil {
+					return nil, fmt.Errorf("line %d: %w", line, err)
+				}
+				output = append(output, Document{Line: line, raw: raw})
+			}
+			if _, err := decoder.Token(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		var raw json.RawMessage
+		err := decoder.Decode(&raw)
+		if errors.Is(err, io.EOF) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		output = append(output, Document{Line: line, raw: raw})
+	}
+}
+
+func readYamlDocuments(data []byte) ([]Document, error) {
+	var output []Document
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document yaml.Node
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(document.Content) == 0 {
+			continue
+		}
+		root := document.Content[0]
+		if root.Kind == yaml.SequenceNode {
+			for _, item := range root.Content {
+				output = append(output, Document{Line: item.Line, node: item})
+			}
+			continue
+		}
+		output = append(output, Document{Line: root.Line, node: root})
+	}
+}
+
+// ValidateRequired checks that every field tagged `validate:"required"` is set, including in nested structs
+//
+// Fields are named by their json tag in the error so the names match the input.
+func ValidateRequired(value any) error {
+	return validateRequired(reflect.Indirect(reflect.ValueOf(value)), "")
+}
+
+func validateRequired(value reflect.Value, prefix string) error {
+	if value.Kind() != reflect.Struct {
+		return nil
+	}
+	var errs []error
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "" {
+			name = field.Name
+		}
+		name = prefix + name
+		fieldValue := value.Field(i)
+		if slices.Contains(strings.Split(field.Tag.Get("validate"), ","), "required") && fieldValue.IsZero() {
+			errs = append(errs, fmt.Errorf("'%s' is required", name))
+			continue
+		}
+		if err := validateRequired(reflect.Indirect(fieldValue), name+"."); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
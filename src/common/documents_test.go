@@ -0,0 +1,80 @@
+package common_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+type documentEvent struct {
+	Service  string         `validate:"required" json:"service"`
+	At       time.Time      `validate:"required" json:"deployed_at" yaml:"deployed-at"`
+	Deployer documentPerson `json:"deployer"`
+	Number   string         `json:"number,omitempty"`
+}
+
+type documentPerson struct {
+	Email string `validate:"required" json:"email"`
+}
+
+func TestReadDocumentsNDJSON(t *testing.T) {
+	// Arrange
+	data := []byte("{\"service\": \"cart\"}\n\n{\"service\": \"web\"}\n{\"service\": \"api\"}\n")
+	// Act
+	documents, err := common.ReadDocuments(data, "ndjson")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(documents))
+	autopilot.Equals(t, []int{1, 3, 4}, []int{documents[0].Line, documents[1].Line, documents[2].Line})
+	var event documentEvent
+	autopilot.Ok(t, documents[1].Decode(&event))
+	autopilot.Equals(t, "web", event.Service)
+}
+
+func TestReadDocumentsJSONList(t *testing.T) {
+	// Arrange
+	data := []byte("[\n  {\"service\": \"cart\"},\n  {\"service\": \"web\"}\n]\n")
+	// Act
+	documents, err := common.ReadDocuments(data, "json")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 2, len(documents))
+	autopilot.Equals(t, 3, documents[1].Line)
+}
+
+func TestReadDocumentsYAML(t *testing.T) {
+	// Arrange
+	data := []byte("service: cart\ndeployed-at: 2024-01-02T03:04:05Z\n---\n- service: web\n- service: api\n")
+	// Act
+	documents, err := common.ReadDocuments(data, "yaml")
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 3, len(documents))
+	autopilot.Equals(t, []int{1, 4, 5}, []int{documents[0].Line, documents[1].Line, documents[2].Line})
+	var event documentEvent
+	autopilot.Ok(t, documents[0].Decode(&event))
+	autopilot.Equals(t, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), event.At)
+}
+
+func TestReadDocumentsInvalid(t *testing.T) {
+	// Arrange
+	data := []byte("{\"service\": \"cart\"}\n{\"service\": \n")
+	// Act
+	_, err := common.ReadDocuments(data, "ndjson")
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for the truncated line")
+}
+
+func TestValidateRequired(t *testing.T) {
+	// Arrange
+	valid := documentEvent{Service: "cart", At: time.Now(), Deployer: documentPerson{Email: "a@b.com"}}
+	invalid := &documentEvent{Number: "1"}
+	// Act
+	validErr := common.ValidateRequired(valid)
+	invalidErr := common.ValidateRequired(invalid)
+	// Assert
+	autopilot.Ok(t, validErr)
+	autopilot.Equals(t, "'service' is required\n'deployed_at' is required\n'deployer.email' is required", invalidErr.Error())
+}
@@ -0,0 +1,152 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mattn/go-isatty"
+)
+
+const maxBackoff = 30 * time.Second
+
+// Executor runs tasks on a pool of workers and retries the tasks that are rate limited or hit a server error
+type Executor struct {
+	Concurrency int
+	Retries     int
+	Backoff     time.Duration
+	// Retryable decides which errors are retried, IsRetryable is used when it is nil
+	Retryable func(error) bool
+	// Label is shown next to the progress bar drawn on Progress, a nil Progress disables it
+	Label    string
+	Progress io.Writer
+}
+
+// NewExecutor returns an executor that draws its progress bar on stderr when it is a terminal
+func NewExecutor(concurrency int, label string) *Executor {
+	executor := &Executor{
+		Concurrency: concurrency,
+		Retries:     3,
+		Backoff:     time.Second,
+		Label:       label,
+	}
+	fd := os.Stderr.Fd()
+	if isatty.IsTerminal(fd) || isatty.IsCygwinTerminal(fd) {
+		executor.Progress = os.Stderr
+	}
+	return executor
+}
+
+// Run calls task with every index from 0 to count and returns the error of each task by index
+//
+// The returned slice always has count items, tasks that succeeded have a nil error.
+func (e *Executor) Run(count int, task func(i int) error) []error {
+	errs := make([]error, count)
+	progress := &progressBar{out: e.Progress, label: e.Label, total: count}
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for range max(1, min(e.Concurrency, count)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				errs[i] = e.retry(func() error { return task(i) })
+				progress.increment()
+			}
+		}()
+	}
+	for i := range count {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+	progress.finish()
+	return errs
+}
+
+func (e *Executor) retry(task func() error) error {
+	retryable := e.Retryable
+	if retryable == nil {
+		retryable = IsRetryable
+	}
+	err := task()
+	for attempt := 0; err != nil && attempt < e.Retries && retryable(err); attempt++ {
+		time.Sleep(backoffDelay(e.Backoff, attempt))
+		err = task()
+	}
+	return err
+}
+
+// backoffDelay doubles the delay on every attempt and adds up to 50% jitter so workers don't retry in lockstep
+func backoffDelay(base time.Duration, attempt int) time.Duration {
+	if base <= 0 {
+		return 0
+	}
+	delay := min(base<<attempt, maxBackoff)
+	return delay + rand.N(delay/2+1)
+}
+
+// IsRetryable reports if the error is a network timeout or carries the HTTP status of a rate limit or
+// a server error, like the network errors of the GraphQL client that have a StatusCode method
+//
+// A request that failed in the transport without a response, a *url.Error from the HTTP client,
+// is retried as well unless it was cancelled.
+func IsRetryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var statusErr interface{ StatusCode() int }
+	if errors.As(err, &statusErr) {
+		return IsRetryableStatus(statusErr.StatusCode())
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// IsRetryableStatus reports if the HTTP status is a rate limit or a server error that may succeed later
+func IsRetryableStatus(code int) bool {
+	switch code {
+	case 429, 500, 502, 503, 504:
+		return true
+	}
+	return false
+}
+
+type progressBar struct {
+	mutex sync.Mutex
+	out   io.Writer
+	label string
+	total int
+	done  int
+}
+
+func (p *progressBar) increment() {
+	if p.out == nil {
+		return
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.done++
+	const width = 30
+	filled := width * p.done / max(p.total, 1)
+	fmt.Fprintf(p.out, "\r%s [%s%s] %d/%d", p.label, strings.Repeat("=", filled), strings.Repeat(" ", width-filled), p.done, p.total)
+}
+
+func (p *progressBar) finish() {
+	if p.out == nil || p.done == 0 {
+		return
+	}
+	fmt.Fprintln(p.out)
+}
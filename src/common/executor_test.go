@@ -0,0 +1,122 @@
+package common_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"syscall"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+type statusError int
+
+func (e statusError) Error() string {
+	return fmt.Sprintf("non-200 OK status code: %d", int(e))
+}
+
+func (e statusError) StatusCode() int {
+	return int(e)
+}
+
+func TestExecutorRunsEveryTask(t *testing.T) {
+	// Arrange
+	executor := &common.Executor{Concurrency: 4}
+	results := make([]int, 10)
+	// Act
+	errs := executor.Run(len(results), func(i int) error {
+		results[i] = i * i
+		return nil
+	})
+	// Assert
+	autopilot.Ok(t, errors.Join(errs...))
+	autopilot.Equals(t, []int{0, 1, 4, 9, 16, 25, 36, 49, 64, 81}, results)
+}
+
+func TestExecutorRetriesRateLimits(t *testing.T) {
+	// Arrange
+	executor := &common.Executor{Concurrency: 2, Retries: 3}
+	var calls atomic.Int32
+	// Act
+	errs := executor.Run(1, func(i int) error {
+		if calls.Add(1) < 3 {
+			return statusError(429)
+		}
+		return nil
+	})
+	// Assert
+	autopilot.Ok(t, errs[0])
+	autopilot.Equals(t, int32(3), calls.Load())
+}
+
+func TestExecutorDoesNotRetryOtherErrors(t *testing.T) {
+	// Arrange
+	executor := &common.Executor{Concurrency: 1, Retries: 3}
+	var calls atomic.Int32
+	// Act
+	errs := executor.Run(2, func(i int) error {
+		calls.Add(1)
+		if i == 1 {
+			return fmt.Errorf("service 'cart' not found")
+		}
+		return nil
+	})
+	// Assert
+	autopilot.Ok(t, errs[0])
+	autopilot.Assert(t, errs[1] != nil, "expected the second task to fail")
+	autopilot.Equals(t, int32(2), calls.Load())
+}
+
+func TestExecutorCustomRetryable(t *testing.T) {
+	// Arrange
+	unreachable := errors.New("connection refused")
+	executor := &common.Executor{Concurrency: 1, Retries: 2, Retryable: func(err error) bool { return errors.Is(err, unreachable) }}
+	var calls atomic.Int32
+	// Act
+	errs := executor.Run(1, func(i int) error {
+		calls.Add(1)
+		return unreachable
+	})
+	// Assert
+	autopilot.Equals(t, unreachable, errs[0])
+	autopilot.Equals(t, int32(3), calls.Load())
+}
+
+func TestExecutorProgress(t *testing.T) {
+	// Arrange
+	var b bytes.Buffer
+	executor := &common.Executor{Concurrency: 1, Label: "services", Progress: &b}
+	// Act
+	executor.Run(2, func(i int) error { return nil })
+	// Assert
+	autopilot.Assert(t, strings.HasSuffix(b.String(), "services [==============================] 2/2\n"), "unexpected progress %q", b.String())
+}
+
+func TestIsRetryable(t *testing.T) {
+	// Arrange
+	// Act
+	// Assert
+	autopilot.Equals(t, true, common.IsRetryable(statusError(503)))
+	autopilot.Equals(t, true, common.IsRetryable(fmt.Errorf("error importing service 'cart': %w", statusError(429))))
+	autopilot.Equals(t, false, common.IsRetryable(statusError(401)))
+	autopilot.Equals(t, false, common.IsRetryable(fmt.Errorf("service 'api-500' not found")))
+	autopilot.Equals(t, false, common.IsRetryable(fmt.Errorf("status code: 503 Service Unavailable")))
+	autopilot.Equals(t, false, common.IsRetryable(nil))
+}
+
+func TestIsRetryableTransport(t *testing.T) {
+	// Arrange
+	reset := &url.Error{Op: "Post", URL: "https://app.opslevel.com/graphql", Err: syscall.ECONNRESET}
+	cancelled := &url.Error{Op: "Post", URL: "https://app.opslevel.com/graphql", Err: context.Canceled}
+	// Act
+	// Assert
+	autopilot.Equals(t, true, common.IsRetryable(fmt.Errorf("error importing service 'cart': %w", reset)))
+	autopilot.Equals(t, true, common.IsRetryable(errors.Join(errors.New("request_error"), statusError(502))))
+	autopilot.Equals(t, false, common.IsRetryable(cancelled))
+}
@@ -0,0 +1,93 @@
+package common
+
+import (
+	"io/fs"
+	"path"
+	"path/filepath"
+
+	"github.com/gobwas/glob"
+)
+
+// FileTree is the directory a policy reads files from
+//
+// Exclude holds glob patterns that are matched against the path relative to Root and the name of
+// every file or directory, a matching directory is skipped entirely.
+type FileTree struct {
+	Root    string
+	Exclude []string
+}
+
+// Path resolves a path relative to the root, absolute paths are kept as is
+func (t FileTree) Path(name string) string {
+	if filepath.IsAbs(name) {
+		return name
+	}
+	return filepath.Join(t.root(), filepath.FromSlash(name))
+}
+
+// Walk lists every file and directory under the root that is not excluded as slash separated relative paths, the root itself is not listed
+func (t FileTree) Walk() ([]string, error) {
+	var output []string
+	err := t.walk(func(name string, entry fs.DirEntry) {
+		if name != "." {
+			output = append(output, name)
+		}
+	})
+	return output, err
+}
+
+// Glob lists the files under the root whose relative path matches pattern, '*' stays within a directory and '**' crosses directories
+func (t FileTree) Glob(pattern string) ([]string, error) {
+	matcher, err := glob.Compile(pattern, '/')
+	if err != nil {
+		return nil, err
+	}
+	output := []string{}
+	err = t.walk(func(name string, entry fs.DirEntry) {
+		if !entry.IsDir() && matcher.Match(name) {
+			output = append(output, name)
+		}
+	})
+	return output, err
+}
+
+func (t FileTree) root() string {
+	if t.Root == "" {
+		return "."
+	}
+	return t.Root
+}
+
+func (t FileTree) walk(visit func(name string, entry fs.DirEntry)) error {
+	excludes := make([]glob.Glob, len(t.Exclude))
+	for i, pattern := range t.Exclude {
+		matcher, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return err
+		}
+		excludes[i] = matcher
+	}
+	root := t.root()
+	return filepath.WalkDir(root, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		name, err := filepath.Rel(root, file)
+		if err != nil {
+			return err
+		}
+		name = filepath.ToSlash(name)
+		if name != "." {
+			for _, exclude := range excludes {
+				if exclude.Match(name) || exclude.Match(path.Base(name)) {
+					if entry.IsDir() {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+			}
+		}
+		visit(name, entry)
+		return nil
+	})
+}
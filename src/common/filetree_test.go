@@ -0,0 +1,34 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestFileTree(t *testing.T) {
+	// Arrange
+	root := t.TempDir()
+	for _, name := range []string{"go.mod", "cmd/main.go", "cmd/deploy/deploy.go", "node_modules/lib/index.js", "vendor/dep/dep.go", ".git/HEAD"} {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		autopilot.Ok(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		autopilot.Ok(t, os.WriteFile(path, []byte(name), 0o644))
+	}
+	tree := common.FileTree{Root: root, Exclude: []string{".git", "node_modules", "vendor/**"}}
+	// Act
+	files, err := tree.Walk()
+	autopilot.Ok(t, err)
+	goFiles, err := tree.Glob("**.go")
+	autopilot.Ok(t, err)
+	cmdFiles, err := tree.Glob("cmd/*.go")
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, []string{"cmd", "cmd/deploy", "cmd/deploy/deploy.go", "cmd/main.go", "go.mod", "vendor"}, files)
+	autopilot.Equals(t, []string{"cmd/deploy/deploy.go", "cmd/main.go"}, goFiles)
+	autopilot.Equals(t, []string{"cmd/main.go"}, cmdFiles)
+	autopilot.Equals(t, filepath.Join(root, "cmd", "main.go"), tree.Path("cmd/main.go"))
+	autopilot.Equals(t, "/etc/hosts", tree.Path("/etc/hosts"))
+}
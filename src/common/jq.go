@@ -0,0 +1,45 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/itchyny/gojq"
+)
+
+// EvaluateJQ runs the jq expression against the input and returns every result
+func EvaluateJQ(expression string, input any) ([]any, error) {
+	query, err := gojq.Parse(expression)
+	if err != nil {
+		return nil, fmt.Errorf("invalid expression '%s': %w", expression, err)
+	}
+	var output []any
+	iter := query.Run(toPlain(input))
+	for {
+		result, ok := iter.Next()
+		if !ok {
+			return output, nil
+		}
+		if err, ok := result.(error); ok {
+			return nil, err
+		}
+		output = append(output, result)
+	}
+}
+
+// IsTruthy follows jq where only false and null are false
+func IsTruthy(value any) bool {
+	return value != nil && value != false
+}
+
+// FormatJQValue prints strings as is and everything else as JSON like 'jq -r'
+func FormatJQValue(value any) string {
+	if text, ok := value.(string); ok {
+		return text
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
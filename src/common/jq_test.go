@@ -0,0 +1,30 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+var jqPayload = map[string]any{
+	"service": "cart",
+	"scan": map[string]any{
+		"critical": float64(2),
+		"tags":     []any{"prod", "pci"},
+	},
+}
+
+func TestEvaluateJQ(t *testing.T) {
+	// Arrange
+	// Act
+	selected, err := common.EvaluateJQ(".service", jqPayload)
+	autopilot.Ok(t, err)
+	condition, err := common.EvaluateJQ(".scan.critical == 0", jqPayload)
+	autopilot.Ok(t, err)
+	_, invalidErr := common.EvaluateJQ(".scan[", jqPayload)
+	// Assert
+	autopilot.Equals(t, []any{"cart"}, selected)
+	autopilot.Equals(t, false, common.IsTruthy(condition[0]))
+	autopilot.Assert(t, invalidErr != nil, "expected an error for an invalid expression")
+}
@@ -0,0 +1,73 @@
+package common
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+)
+
+// JUnitTestCase is a single test case of a JUnit XML report, a non-empty Failure marks it as failed
+type JUnitTestCase struct {
+	Name      string
+	ClassName string
+	Failure   string
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes the test cases as a single JUnit XML test suite that CI systems can show as a test report
+func WriteJUnit(w io.Writer, suite string, cases []JUnitTestCase) error {
+	output := junitTestSuite{Name: suite, Tests: len(cases)}
+	for _, item := range cases {
+		testCase := junitTestCase{Name: item.Name, ClassName: item.ClassName}
+		if item.Failure != "" {
+			output.Failures++
+			testCase.Failure = &junitFailure{Message: item.Failure, Text: item.Failure}
+		}
+		output.Cases = append(output.Cases, testCase)
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(junitTestSuites{Suites: []junitTestSuite{output}}); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+// WriteJUnitFile writes the JUnit XML report to path
+func WriteJUnitFile(path string, suite string, cases []JUnitTestCase) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := WriteJUnit(file, suite, cases); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,32 @@
+package common_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestWriteJUnit(t *testing.T) {
+	// Arrange
+	buf := bytes.Buffer{}
+	cases := []common.JUnitTestCase{
+		{Name: "overall", ClassName: "cart", Failure: ""},
+		{Name: "category Security", ClassName: "cart", Failure: "level is Bronze but Gold & up is required"},
+	}
+	// Act
+	err := common.WriteJUnit(&buf, "maturity-gate", cases)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="maturity-gate" tests="2" failures="1">
+    <testcase name="overall" classname="cart"></testcase>
+    <testcase name="category Security" classname="cart">
+      <failure message="level is Bronze but Gold &amp; up is required">level is Bronze but Gold &amp; up is required</failure>
+    </testcase>
+  </testsuite>
+</testsuites>
+`, buf.String())
+}
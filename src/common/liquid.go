@@ -0,0 +1,62 @@
+package common
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	liquidMarkupExp      = regexp.MustCompile(`(?s){{-?\s*(.*?)\s*-?}}|{%.*?%}`)
+	liquidPathExp        = regexp.MustCompile(`^[A-Za-z_][\w-]*(\.[A-Za-z_][\w-]*|\[\d+\])*$`)
+	liquidPathSegmentExp = regexp.MustCompile(`[A-Za-z_][\w-]*|\[(\d+)\]`)
+)
+
+// RenderMessage previews the result message of a custom event check
+//
+// OpsLevel renders messages as Liquid templates with the payload as 'data' and the result as 'check.passed'
+// and 'check.failed'. The preview only substitutes plain variables like '{{ data.scan.tags[0] }}', a message
+// with tags or filters is returned as is along with an error.
+func RenderMessage(message string, payload any, passed bool) (string, error) {
+	scope := map[string]any{
+		"data":  toPlain(payload),
+		"check": map[string]any{"passed": passed, "failed": !passed},
+	}
+	var unsupported []string
+	output := liquidMarkupExp.ReplaceAllStringFunc(message, func(markup string) string {
+		path := liquidMarkupExp.FindStringSubmatch(markup)[1]
+		if strings.HasPrefix(markup, "{%") || !liquidPathExp.MatchString(path) {
+			unsupported = append(unsupported, markup)
+			return markup
+		}
+		if value := lookupLiquid(path, scope); value != nil {
+			return FormatJQValue(value)
+		}
+		return ""
+	})
+	if len(unsupported) > 0 {
+		return message, fmt.Errorf("only plain variables can be previewed but found %s", quoteList(unsupported))
+	}
+	return output, nil
+}
+
+// lookupLiquid returns the value of a path like 'data.scan.tags[0]' or nil when any part is missing
+func lookupLiquid(path string, scope map[string]any) any {
+	var value any = scope
+	for _, segment := range liquidPathSegmentExp.FindAllStringSubmatch(path, -1) {
+		switch current := value.(type) {
+		case map[string]any:
+			value = current[segment[0]]
+		case []any:
+			index, err := strconv.Atoi(segment[1])
+			if err != nil || index >= len(current) {
+				return nil
+			}
+			value = current[index]
+		default:
+			return nil
+		}
+	}
+	return value
+}
@@ -0,0 +1,35 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestRenderMessage(t *testing.T) {
+	// Arrange
+	message := "{{ data.service }} has {{data.scan.critical}} critical issues in {{ data.scan.tags }}{{ data.missing }} tagged {{ data.scan.tags[1] }}, passed: {{ check.passed }}"
+	// Act
+	result, err := common.RenderMessage(message, jqPayload, true)
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, `cart has 2 critical issues in ["prod","pci"] tagged pci, passed: true`, result)
+}
+
+func TestRenderMessageUnsupported(t *testing.T) {
+	// Arrange
+	messages := []string{
+		"{{ data.service | upcase }}",
+		"{% assign count = data.scan.critical %}",
+		"{% if check.passed %}No critical issues{% endif %}",
+		"{{ data.scan[ }}",
+	}
+	for _, message := range messages {
+		// Act
+		result, err := common.RenderMessage(message, jqPayload, true)
+		// Assert
+		autopilot.Assert(t, err != nil, "expected an error for %q", message)
+		autopilot.Equals(t, message, result)
+	}
+}
@@ -0,0 +1,128 @@
+package common
+
+import (
+	"cmp"
+	"slices"
+)
+
+// OverallCategory is the key of the overall level in ServiceLevels.Levels
+const OverallCategory = "Overall"
+
+// ServiceLevels is the maturity of a service as the level name of each rubric category
+//
+// Id matches the service across snapshots so that renamed services and services that share a name
+// are compared correctly, Service is only used for display.
+type ServiceLevels struct {
+	Id      string
+	Service string
+	Owner   string
+	Levels  map[string]string
+}
+
+// MaturityChange is a category of a service whose level changed between two snapshots
+//
+// Change is the number of levels moved, negative when the service moved down.
+type MaturityChange struct {
+	Service  string `json:"service"`
+	Owner    string `json:"owner"`
+	Category string `json:"category"`
+	From     string `json:"from"`
+	To       string `json:"to"`
+	Change   int    `json:"change"`
+}
+
+// TeamMaturityRollup sums the changes of the services owned by a team
+type TeamMaturityRollup struct {
+	Team     string `json:"team"`
+	Services int    `json:"services"`
+	Up       int    `json:"up"`
+	Down     int    `json:"down"`
+	Net      int    `json:"net"`
+}
+
+// DiffMaturity compares two snapshots by the index of each level
+//
+// Only services and categories found in both snapshots are compared, levels missing from
+// levelIndex are skipped since they can not be ordered.
+func DiffMaturity(before, after []ServiceLevels, levelIndex map[string]int) []MaturityChange {
+	previous := map[string]ServiceLevels{}
+	for _, service := range before {
+		previous[service.Id] = service
+	}
+	output := []MaturityChange{}
+	for _, service := range after {
+		old, ok := previous[service.Id]
+		if !ok {
+			continue
+		}
+		for category, level := range service.Levels {
+			oldLevel, ok := old.Levels[category]
+			if !ok || oldLevel == level {
+				continue
+			}
+			from, fromOk := levelIndex[oldLevel]
+			to, toOk := levelIndex[level]
+			if !fromOk || !toOk || from == to {
+				continue
+			}
+			output = append(output, MaturityChange{
+				Service:  service.Service,
+				Owner:    service.Owner,
+				Category: category,
+				From:     oldLevel,
+				To:       level,
+				Change:   to - from,
+			})
+		}
+	}
+	slices.SortFunc(output, func(a, b MaturityChange) int {
+		return cmp.Or(
+			cmp.Compare(a.Service, b.Service),
+			cmp.Compare(categoryOrder(a.Category), categoryOrder(b.Category)),
+			cmp.Compare(a.Category, b.Category),
+		)
+	})
+	return output
+}
+
+// RollupMaturityByTeam counts the services of each team and how many of their categories moved up or down
+func RollupMaturityByTeam(services []ServiceLevels, changes []MaturityChange) []TeamMaturityRollup {
+	teams := map[string]*TeamMaturityRollup{}
+	team := func(owner string) *TeamMaturityRollup {
+		if owner == "" {
+			owner = "(no owner)"
+		}
+		if _, ok := teams[owner]; !ok {
+			teams[owner] = &TeamMaturityRollup{Team: owner}
+		}
+		return teams[owner]
+	}
+	for _, service := range services {
+		team(service.Owner).Services++
+	}
+	for _, change := range changes {
+		rollup := team(change.Owner)
+		if change.Change > 0 {
+			rollup.Up++
+		} else {
+			rollup.Down++
+		}
+		rollup.Net += change.Change
+	}
+	output := make([]TeamMaturityRollup, 0, len(teams))
+	for _, rollup := range teams {
+		output = append(output, *rollup)
+	}
+	slices.SortFunc(output, func(a, b TeamMaturityRollup) int {
+		return cmp.Compare(a.Team, b.Team)
+	})
+	return output
+}
+
+// categoryOrder sorts the overall level before the categories
+func categoryOrder(category string) int {
+	if category == OverallCategory {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,40 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+var maturityLevelIndex = map[string]int{"Beginner": 0, "Bronze": 1, "Silver": 2, "Gold": 3}
+
+func TestDiffMaturity(t *testing.T) {
+	// Arrange
+	before := []common.ServiceLevels{
+		{Id: "1", Service: "shopping_cart", Owner: "platform", Levels: map[string]string{"Overall": "Bronze", "Security": "Bronze", "Reliability": "Gold"}},
+		{Id: "2", Service: "search", Owner: "discovery", Levels: map[string]string{"Overall": "Silver", "Security": "Silver"}},
+		{Id: "3", Service: "search", Owner: "platform", Levels: map[string]string{"Overall": "Gold"}},
+		{Id: "4", Service: "retired", Owner: "platform", Levels: map[string]string{"Overall": "Gold"}},
+	}
+	after := []common.ServiceLevels{
+		{Id: "1", Service: "cart", Owner: "platform", Levels: map[string]string{"Overall": "Silver", "Security": "Gold", "Reliability": "Silver"}},
+		{Id: "2", Service: "search", Owner: "discovery", Levels: map[string]string{"Overall": "Silver", "Security": "Custom"}},
+		{Id: "3", Service: "search", Owner: "platform", Levels: map[string]string{"Overall": "Gold"}},
+		{Id: "5", Service: "new", Owner: "", Levels: map[string]string{"Overall": "Bronze"}},
+	}
+	// Act
+	changes := common.DiffMaturity(before, after, maturityLevelIndex)
+	teams := common.RollupMaturityByTeam(after, changes)
+	// Assert
+	autopilot.Equals(t, []common.MaturityChange{
+		{Service: "cart", Owner: "platform", Category: "Overall", From: "Bronze", To: "Silver", Change: 1},
+		{Service: "cart", Owner: "platform", Category: "Reliability", From: "Gold", To: "Silver", Change: -1},
+		{Service: "cart", Owner: "platform", Category: "Security", From: "Bronze", To: "Gold", Change: 2},
+	}, changes)
+	autopilot.Equals(t, []common.TeamMaturityRollup{
+		{Team: "(no owner)", Services: 1},
+		{Team: "discovery", Services: 1},
+		{Team: "platform", Services: 2, Up: 2, Down: 1, Net: 2},
+	}, teams)
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strings"
 	"text/tabwriter"
@@ -24,6 +25,10 @@ func PrettyPrint(object interface{}) {
 }
 
 func NewTabWriter(headers ...string) *tabwriter.Writer {
+	return NewTabWriterTo(os.Stdout, headers...)
+}
+
+func NewTabWriterTo(out io.Writer, headers ...string) *tabwriter.Writer {
 	longestHeader := 0
 	var headerFormat strings.Builder
 	headersCasted := make([]interface{}, len(headers))
@@ -36,7 +41,7 @@ func NewTabWriter(headers ...string) *tabwriter.Writer {
 		}
 	}
 	headerFormat.WriteString("\n")
-	w := tabwriter.NewWriter(os.Stdout, longestHeader, longestHeader, 2, ' ', 0)
+	w := tabwriter.NewWriter(out, longestHeader, longestHeader, 2, ' ', 0)
 	if !viper.GetBool("no-headers") {
 		fmt.Fprintf(w, headerFormat.String(), headersCasted...)
 	}
@@ -0,0 +1,190 @@
+package common
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Predicate is a check predicate that can be evaluated locally, Type is one of the PredicateTypeEnum values
+type Predicate struct {
+	Type  string
+	Value string
+}
+
+// IsNegative is true for predicates that must hold for every value instead of any value
+func (p Predicate) IsNegative() bool {
+	return strings.HasPrefix(p.Type, "does_not_")
+}
+
+// Evaluate checks the predicate against a value, a nil value is one that does not exist
+func (p Predicate) Evaluate(value *string) (bool, error) {
+	switch p.Type {
+	case "exists":
+		return value != nil, nil
+	case "does_not_exist":
+		return value == nil, nil
+	}
+	if value == nil {
+		return p.IsNegative(), nil
+	}
+	switch p.Type {
+	case "equals":
+		return *value == p.Value, nil
+	case "does_not_equal":
+		return *value != p.Value, nil
+	case "contains":
+		return strings.Contains(*value, p.Value), nil
+	case "does_not_contain":
+		return !strings.Contains(*value, p.Value), nil
+	case "starts_with":
+		return strings.HasPrefix(*value, p.Value), nil
+	case "ends_with":
+		return strings.HasSuffix(*value, p.Value), nil
+	case "matches_regex", "matches":
+		return p.matchRegex(*value)
+	case "does_not_match_regex", "does_not_match":
+		matched, err := p.matchRegex(*value)
+		return !matched, err
+	case "greater_than_or_equal_to", "less_than_or_equal_to":
+		return p.compareNumber(*value)
+	case "satisfies_version_constraint":
+		return SatisfiesVersionConstraint(strings.TrimSpace(*value), p.Value)
+	case "satisfies_jq_expression":
+		return p.satisfiesJQ(*value)
+	default:
+		return false, fmt.Errorf("predicate type '%s' can not be evaluated locally", p.Type)
+	}
+}
+
+func (p Predicate) matchRegex(value string) (bool, error) {
+	exp, err := regexp.Compile(p.Value)
+	if err != nil {
+		return false, fmt.Errorf("invalid regex '%s': %w", p.Value, err)
+	}
+	return exp.MatchString(value), nil
+}
+
+func (p Predicate) compareNumber(value string) (bool, error) {
+	expected, err := strconv.ParseFloat(strings.TrimSpace(p.Value), 64)
+	if err != nil {
+		return false, fmt.Errorf("predicate value '%s' is not a number", p.Value)
+	}
+	actual, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return false, nil
+	}
+	if p.Type == "greater_than_or_equal_to" {
+		return actual >= expected, nil
+	}
+	return actual <= expected, nil
+}
+
+// satisfiesJQ parses the value as JSON or YAML and checks that the first result of the expression is truthy
+func (p Predicate) satisfiesJQ(value string) (bool, error) {
+	var document any
+	if err := json.Unmarshal([]byte(value), &document); err != nil {
+		if err := yaml.Unmarshal([]byte(value), &document); err != nil {
+			return false, nil
+		}
+	}
+	results, err := EvaluateJQ(p.Value, document)
+	if err != nil {
+		return false, err
+	}
+	return len(results) > 0 && IsTruthy(results[0]), nil
+}
+
+// versionOperators are ordered so that the longer operators are matched first
+var versionOperators = []string{"~>", ">=", "<=", "==", "!=", ">", "<", "=", "~", "^"}
+
+// SatisfiesVersionConstraint checks a version against comma separated constraints like '>= 1.2, < 2' or '~> 3.1'
+//
+// Versions are compared by their numeric dot separated parts, pre-release and build suffixes are ignored.
+func SatisfiesVersionConstraint(version string, constraint string) (bool, error) {
+	actual, err := parseVersion(version)
+	if err != nil {
+		return false, nil
+	}
+	for _, item := range strings.Split(constraint, ",") {
+		item = strings.TrimSpace(item)
+		var operator string
+		for _, prefix := range versionOperators {
+			if strings.HasPrefix(item, prefix) {
+				operator = prefix
+				break
+			}
+		}
+		expected, err := parseVersion(strings.TrimSpace(strings.TrimPrefix(item, operator)))
+		if err != nil {
+			return false, fmt.Errorf("invalid version constraint '%s'", item)
+		}
+		compared := compareVersions(actual, expected)
+		var ok bool
+		switch operator {
+		case "", "=", "==":
+			ok = compared == 0
+		case "!=":
+			ok = compared != 0
+		case ">":
+			ok = compared > 0
+		case ">=":
+			ok = compared >= 0
+		case "<":
+			ok = compared < 0
+		case "<=":
+			ok = compared <= 0
+		case "~>", "~":
+			// '~> 3.1' allows '>= 3.1, < 4' and '~> 3.1.2' allows '>= 3.1.2, < 3.2'
+			upper := append([]int{}, expected[:max(len(expected)-1, 1)]...)
+			upper[len(upper)-1]++
+			ok = compared >= 0 && compareVersions(actual, upper) < 0
+		case "^":
+			upper := []int{expected[0] + 1}
+			ok = compared >= 0 && compareVersions(actual, upper) < 0
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func parseVersion(value string) ([]int, error) {
+	value = strings.TrimPrefix(strings.TrimPrefix(value, "v"), "V")
+	if index := strings.IndexAny(value, "-+"); index >= 0 {
+		value = value[:index]
+	}
+	var output []int
+	for _, part := range strings.Split(value, ".") {
+		number, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version '%s'", value)
+		}
+		output = append(output, number)
+	}
+	return output, nil
+}
+
+func compareVersions(a, b []int) int {
+	for i := range max(len(a), len(b)) {
+		var x, y int
+		if i < len(a) {
+			x = a[i]
+		}
+		if i < len(b) {
+			y = b[i]
+		}
+		if x != y {
+			if x < y {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,81 @@
+package common_test
+
+import (
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func TestPredicateEvaluate(t *testing.T) {
+	// Arrange
+	value := "FROM golang:1.24-alpine\nRUN go build"
+	cases := []struct {
+		predicate common.Predicate
+		value     *string
+		expected  bool
+	}{
+		{common.Predicate{Type: "exists"}, &value, true},
+		{common.Predicate{Type: "exists"}, nil, false},
+		{common.Predicate{Type: "does_not_exist"}, nil, true},
+		{common.Predicate{Type: "contains", Value: "golang"}, &value, true},
+		{common.Predicate{Type: "contains", Value: "golang"}, nil, false},
+		{common.Predicate{Type: "does_not_contain", Value: "ubuntu"}, &value, true},
+		{common.Predicate{Type: "does_not_contain", Value: "ubuntu"}, nil, true},
+		{common.Predicate{Type: "starts_with", Value: "FROM"}, &value, true},
+		{common.Predicate{Type: "matches_regex", Value: `golang:1\.2\d`}, &value, true},
+		{common.Predicate{Type: "does_not_match_regex", Value: `golang:1\.2\d`}, &value, false},
+		{common.Predicate{Type: "greater_than_or_equal_to", Value: "3"}, ptr("4"), true},
+		{common.Predicate{Type: "less_than_or_equal_to", Value: "3"}, ptr("4"), false},
+		{common.Predicate{Type: "satisfies_version_constraint", Value: ">= 1.2, < 2"}, ptr("1.10.3"), true},
+		{common.Predicate{Type: "satisfies_jq_expression", Value: ".version == 2"}, ptr("version: 2"), true},
+	}
+	for _, item := range cases {
+		// Act
+		result, err := item.predicate.Evaluate(item.value)
+		// Assert
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, item.expected, result)
+	}
+}
+
+func TestPredicateEvaluateInvalid(t *testing.T) {
+	// Arrange
+	value := "text"
+	// Act
+	_, regexErr := common.Predicate{Type: "matches_regex", Value: "("}.Evaluate(&value)
+	_, typeErr := common.Predicate{Type: "belongs_to", Value: "a"}.Evaluate(&value)
+	// Assert
+	autopilot.Assert(t, regexErr != nil, "expected an error for an invalid regex")
+	autopilot.Assert(t, typeErr != nil, "expected an error for an unsupported type")
+}
+
+func TestSatisfiesVersionConstraint(t *testing.T) {
+	// Arrange
+	cases := []struct {
+		version    string
+		constraint string
+		expected   bool
+	}{
+		{"1.2.3", "1.2.3", true},
+		{"v1.2.3", "= 1.2", false},
+		{"3.4.0", "~> 3.1", true},
+		{"4.0.0", "~> 3.1", false},
+		{"3.1.9", "~> 3.1.2", true},
+		{"3.2.0", "~> 3.1.2", false},
+		{"2.5.0-rc1", "^2.1", true},
+		{"1.0", "!= 1.0.0", false},
+		{"not-a-version", ">= 1", false},
+	}
+	for _, item := range cases {
+		// Act
+		result, err := common.SatisfiesVersionConstraint(item.version, item.constraint)
+		// Assert
+		autopilot.Ok(t, err)
+		autopilot.Equals(t, item.expected, result)
+	}
+}
+
+func ptr(value string) *string {
+	return &value
+}
@@ -0,0 +1,473 @@
+package common
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"math"
+	"os"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// OutputFormats is the help text for every --output flag routed through Printer
+const OutputFormats = "json|yaml|csv|tsv|text|markdown|jsonpath=EXPR|jq=EXPR|template=TEMPLATE|custom-columns=HEADER:PATH,..."
+
+// Column is a single column of tabular output
+//
+// Path is a dot separated path into the item such as 'owner.alias' and is matched case-insensitively
+// against the JSON field names of the item. Slices are joined with ',' unless indexed like 'aliases.0'.
+// Value can be set instead of Path to compute the cell from the original item.
+// Wide columns are only shown when Printer.Wide is set.
+// CSVSeparator joins slices in csv and tsv output instead of ','.
+type Column struct {
+	Header       string
+	Path         string
+	Value        func(item any) string
+	Wide         bool
+	CSVSeparator string
+}
+
+// NewColumn returns a column named header that reads path
+func NewColumn(header, path string) Column {
+	return Column{Header: header, Path: path}
+}
+
+// NewAliasesColumn returns an 'ALIASES' column that reads path and joins the aliases with '/' in csv and tsv output
+func NewAliasesColumn(path string) Column {
+	return Column{Header: "ALIASES", Path: path, CSVSeparator: "/"}
+}
+
+// NewWideColumn returns a column named header that reads path and is only shown in wide mode
+func NewWideColumn(header, path string) Column {
+	return Column{Header: header, Path: path, Wide: true}
+}
+
+// Printer renders command results in the format selected with --output
+//
+// Select replaces the default columns with the named columns or paths, SortBy orders lists by a
+// column or path and Wide adds the wide columns to the defaults.
+type Printer struct {
+	Format  string
+	Columns []Column
+	Select  []string
+	SortBy  string
+	Wide    bool
+	Out     io.Writer
+}
+
+// NewPrinter returns a printer that writes to stdout
+func NewPrinter(format string, columns ...Column) *Printer {
+	return &Printer{
+		Format:  format,
+		Columns: columns,
+		Out:     os.Stdout,
+	}
+}
+
+// PrintList renders a slice of items
+func (p *Printer) PrintList(items any) error {
+	list, err := toSlice(items)
+	if err != nil {
+		return err
+	}
+	if p.SortBy == "" {
+		return p.print(items, list, true)
+	}
+	p.sort(list)
+	return p.print(list, list, true)
+}
+
+// PrintItem renders a single item, the 'text' format prints the item as indented JSON
+func (p *Printer) PrintItem(item any) error {
+	return p.print(item, []any{item}, false)
+}
+
+func (p *Printer) print(value any, items []any, isList bool) error {
+	kind, arg, _ := strings.Cut(p.Format, "=")
+	switch kind {
+	case "", "text":
+		if !isList && len(p.Columns) == 0 {
+			return p.writeJson(value)
+		}
+		return p.writeTable(items, p.columns(items))
+	case "json":
+		return p.writeJson(value)
+	case "yaml":
+		return p.writeYaml(value)
+	case "csv":
+		return p.writeDelimited(items, p.columns(items), ',')
+	case "tsv":
+		return p.writeDelimited(items, p.columns(items), '\t')
+	case "markdown", "md":
+		return p.writeMarkdown(items, p.columns(items))
+	case "jsonpath":
+		return p.writeJq(value, JsonPathToJq(arg))
+	case "jq":
+		return p.writeJq(value, arg)
+	case "template":
+		return p.writeTemplate(value, arg)
+	case "custom-columns":
+		columns, err := ParseCustomColumns(arg)
+		if err != nil {
+			return err
+		}
+		return p.writeTable(items, columns)
+	}
+	return fmt.Errorf("unknown output format '%s' - must be one of: %s", p.Format, OutputFormats)
+}
+
+// columns returns the selected columns, the defaults or every top level field of the first item
+func (p *Printer) columns(items []any) []Column {
+	if len(p.Select) > 0 {
+		return p.selectColumns()
+	}
+	var output []Column
+	for _, column := range p.Columns {
+		if p.Wide || !column.Wide {
+			output = append(output, column)
+		}
+	}
+	if len(output) > 0 || len(items) == 0 {
+		return output
+	}
+	fields, ok := toPlain(items[0]).(map[string]any)
+	if !ok {
+		return []Column{{Header: "VALUE", Value: func(item any) string { return formatCell(toPlain(item)) }}}
+	}
+	for _, key := range sortedKeys(fields) {
+		output = append(output, NewColumn(strings.ToUpper(key), key))
+	}
+	return output
+}
+
+// selectColumns reuses the command's columns where the header matches and reads the path otherwise
+func (p *Printer) selectColumns() []Column {
+	var output []Column
+	for _, item := range p.Select {
+		if column, ok := p.findColumn(item); ok {
+			output = append(output, column)
+			continue
+		}
+		output = append(output, parseColumn(item))
+	}
+	return output
+}
+
+func (p *Printer) findColumn(header string) (Column, bool) {
+	for _, column := range p.Columns {
+		if strings.EqualFold(column.Header, header) {
+			return column, true
+		}
+	}
+	return Column{}, false
+}
+
+// sort orders the items by the column with a matching header or else by the value at that path
+func (p *Printer) sort(items []any) {
+	column, ok := p.findColumn(p.SortBy)
+	if !ok {
+		column = NewColumn(p.SortBy, strings.TrimPrefix(p.SortBy, "."))
+	}
+	keys := make(map[int]any, len(items))
+	indexes := make([]int, len(items))
+	for i, item := range items {
+		indexes[i] = i
+		if column.Value != nil {
+			keys[i] = column.Value(item)
+		} else {
+			keys[i] = LookupPath(toPlain(item), column.Path)
+		}
+	}
+	sort.SliceStable(indexes, func(a, b int) bool {
+		return lessValue(keys[indexes[a]], keys[indexes[b]])
+	})
+	sorted := make([]any, len(items))
+	for i, index := range indexes {
+		sorted[i] = items[index]
+	}
+	copy(items, sorted)
+}
+
+func lessValue(a, b any) bool {
+	left, leftIsNumber := a.(float64)
+	right, rightIsNumber := b.(float64)
+	if leftIsNumber && rightIsNumber {
+		return left < right
+	}
+	return strings.ToLower(formatCell(a)) < strings.ToLower(formatCell(b))
+}
+
+func (p *Printer) writeJson(value any) error {
+	var b bytes.Buffer
+	encoder := json.NewEncoder(&b)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	_, err := p.Out.Write(b.Bytes())
+	return err
+}
+
+func (p *Printer) writeYaml(value any) error {
+	var b bytes.Buffer
+	encoder := yaml.NewEncoder(&b)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(value); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(p.Out, "---\n%s", b.String())
+	return err
+}
+
+func (p *Printer) writeTable(items []any, columns []Column) error {
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.Header
+	}
+	w := NewTabWriterTo(p.Out, headers...)
+	for _, item := range items {
+		fmt.Fprintf(w, "%s\t\n", strings.Join(rowOf(item, columns, false), "\t"))
+	}
+	return w.Flush()
+}
+
+// writeMarkdown writes a GitHub flavored markdown table, escaping the characters that would break a cell
+func (p *Printer) writeMarkdown(items []any, columns []Column) error {
+	escape := strings.NewReplacer("|", "\\|", "\n", "<br>", "\r", "")
+	headers := make([]string, len(columns))
+	separators := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = escape.Replace(column.Header)
+		separators[i] = "---"
+	}
+	if _, err := fmt.Fprintf(p.Out, "| %s |\n| %s |\n", strings.Join(headers, " | "), strings.Join(separators, " | ")); err != nil {
+		return err
+	}
+	for _, item := range items {
+		row := rowOf(item, columns, false)
+		for i, cell := range row {
+			row[i] = escape.Replace(cell)
+		}
+		if _, err := fmt.Fprintf(p.Out, "| %s |\n", strings.Join(row, " | ")); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Printer) writeDelimited(items []any, columns []Column, delimiter rune) error {
+	w := csv.NewWriter(p.Out)
+	w.Comma = delimiter
+	if !viper.GetBool("no-headers") {
+		headers := make([]string, len(columns))
+		for i, column := range columns {
+			headers[i] = column.Header
+		}
+		if err := w.Write(headers); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if err := w.Write(rowOf(item, columns, true)); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
+
+func (p *Printer) writeJq(value any, expression string) error {
+	results, err := EvaluateJQ(expression, value)
+	if err != nil {
+		return err
+	}
+	for _, result := range results {
+		fmt.Fprintln(p.Out, FormatJQValue(result))
+	}
+	return nil
+}
+
+func (p *Printer) writeTemplate(value any, text string) error {
+	tmpl, err := template.New("output").Parse(text)
+	if err != nil {
+		return fmt.Errorf("invalid template: %w", err)
+	}
+	return tmpl.Execute(p.Out, toPlain(value))
+}
+
+// IsTabularOutput reports if the format prints a fixed set of columns instead of whole objects
+func IsTabularOutput(format string) bool {
+	kind, _, _ := strings.Cut(format, "=")
+	switch kind {
+	case "", "text", "csv", "tsv", "markdown", "md", "custom-columns":
+		return true
+	}
+	return false
+}
+
+// JsonPathToJq converts a kubectl style JSONPath expression like '{[*].name}' to the equivalent jq expression
+func JsonPathToJq(expression string) string {
+	output := strings.TrimSpace(expression)
+	output = strings.TrimPrefix(output, "{")
+	output = strings.TrimSuffix(output, "}")
+	output = strings.TrimPrefix(output, "$")
+	output = strings.ReplaceAll(output, "[*]", "[]")
+	if !strings.HasPrefix(output, ".") {
+		output = "." + output
+	}
+	return output
+}
+
+// ParseCustomColumns parses 'HEADER:PATH,HEADER:PATH' into columns
+func ParseCustomColumns(spec string) ([]Column, error) {
+	var output []Column
+	for _, item := range strings.Split(spec, ",") {
+		header, path, ok := strings.Cut(item, ":")
+		if !ok || header == "" || path == "" {
+			return nil, fmt.Errorf("invalid custom column '%s' - must be in the format HEADER:PATH", item)
+		}
+		output = append(output, NewColumn(header, strings.TrimPrefix(path, ".")))
+	}
+	return output, nil
+}
+
+// parseColumn parses 'HEADER:PATH' or just 'PATH' where the header is derived from the path
+func parseColumn(spec string) Column {
+	if header, path, ok := strings.Cut(spec, ":"); ok {
+		return NewColumn(header, strings.TrimPrefix(path, "."))
+	}
+	path := strings.TrimPrefix(spec, ".")
+	return NewColumn(strings.ToUpper(strings.ReplaceAll(path, ".", "_")), path)
+}
+
+// rowOf returns the cells of the item, delimited is set for csv and tsv output
+func rowOf(item any, columns []Column, delimited bool) []string {
+	var plain any
+	row := make([]string, len(columns))
+	for i, column := range columns {
+		if column.Value != nil {
+			row[i] = column.Value(item)
+			continue
+		}
+		if plain == nil {
+			plain = toPlain(item)
+		}
+		value := LookupPath(plain, column.Path)
+		if items, ok := value.([]any); ok && delimited && column.CSVSeparator != "" {
+			cells := make([]string, len(items))
+			for j, cell := range items {
+				cells[j] = formatCell(cell)
+			}
+			row[i] = strings.Join(cells, column.CSVSeparator)
+			continue
+		}
+		row[i] = formatCell(value)
+	}
+	return row
+}
+
+// LookupPath walks a dot separated path through the plain JSON representation of a value
+func LookupPath(value any, path string) any {
+	if path == "" {
+		return value
+	}
+	segment, rest, _ := strings.Cut(path, ".")
+	switch v := value.(type) {
+	case map[string]any:
+		if item, ok := v[segment]; ok {
+			return LookupPath(item, rest)
+		}
+		for key, item := range v {
+			if strings.EqualFold(key, segment) {
+				return LookupPath(item, rest)
+			}
+		}
+		return nil
+	case []any:
+		if index, err := strconv.Atoi(segment); err == nil {
+			if index < 0 || index >= len(v) {
+				return nil
+			}
+			return LookupPath(v[index], rest)
+		}
+		var output []any
+		for _, item := range v {
+			if result := LookupPath(item, path); result != nil {
+				output = append(output, result)
+			}
+		}
+		return output
+	}
+	return nil
+}
+
+func formatCell(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		if v == math.Trunc(v) {
+			return strconv.FormatInt(int64(v), 10)
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			items[i] = formatCell(item)
+		}
+		return strings.Join(items, ",")
+	case map[string]any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	}
+	return fmt.Sprint(value)
+}
+
+// toPlain converts a value to its JSON representation made of maps, slices and scalars
+func toPlain(value any) any {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	var output any
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil
+	}
+	return output
+}
+
+func toSlice(items any) ([]any, error) {
+	v := reflect.ValueOf(items)
+	if v.Kind() == reflect.Pointer {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil, nil
+	}
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return nil, fmt.Errorf("expected a list but got '%T'", items)
+	}
+	output := make([]any, v.Len())
+	for i := range output {
+		output[i] = v.Index(i).Interface()
+	}
+	return output, nil
+}
+
+func sortedKeys(value map[string]any) []string {
+	return slices.Sorted(maps.Keys(value))
+}
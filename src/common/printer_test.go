@@ -0,0 +1,190 @@
+package common_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/spf13/viper"
+
+	"github.com/rocktavious/autopilot"
+)
+
+type printerOwner struct {
+	Alias string `json:"alias"`
+}
+
+type printerItem struct {
+	Name    string       `json:"name"`
+	Aliases []string     `json:"aliases"`
+	Owner   printerOwner `json:"owner"`
+	Index   int          `json:"index"`
+}
+
+var printerItems = []printerItem{
+	{Name: "Cart", Aliases: []string{"cart", "shopping-cart"}, Owner: printerOwner{Alias: "platform"}, Index: 1},
+	{Name: "Search", Owner: printerOwner{Alias: "discovery"}, Index: 2},
+}
+
+func printList(t *testing.T, format string, columns ...common.Column) string {
+	var b bytes.Buffer
+	printer := common.NewPrinter(format, columns...)
+	printer.Out = &b
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	return b.String()
+}
+
+func TestPrinterCSV(t *testing.T) {
+	// Arrange
+	columns := []common.Column{
+		common.NewColumn("NAME", "name"),
+		common.NewColumn("ALIASES", "Aliases"),
+		common.NewColumn("OWNER", "owner.alias"),
+		common.NewColumn("INDEX", "index"),
+	}
+	// Act
+	output := printList(t, "csv", columns...)
+	// Assert
+	autopilot.Equals(t, "NAME,ALIASES,OWNER,INDEX\nCart,\"cart,shopping-cart\",platform,1\nSearch,,discovery,2\n", output)
+}
+
+func TestPrinterCSVAliases(t *testing.T) {
+	// Arrange
+	columns := []common.Column{common.NewColumn("NAME", "name"), common.NewAliasesColumn("aliases")}
+	// Act
+	csv := printList(t, "csv", columns...)
+	text := printList(t, "text", columns...)
+	// Assert
+	autopilot.Equals(t, "NAME,ALIASES\nCart,cart/shopping-cart\nSearch,\n", csv)
+	autopilot.Assert(t, bytes.Contains([]byte(text), []byte("cart,shopping-cart")), "expected text output to join aliases with ',' but got %q", text)
+}
+
+func TestPrinterCSVNoHeaders(t *testing.T) {
+	// Arrange
+	viper.Set("no-headers", true)
+	defer viper.Set("no-headers", false)
+	// Act
+	output := printList(t, "tsv", common.NewColumn("NAME", "name"))
+	// Assert
+	autopilot.Equals(t, "Cart\nSearch\n", output)
+}
+
+func TestPrinterMarkdown(t *testing.T) {
+	// Arrange
+	columns := []common.Column{
+		common.NewColumn("NAME", "name"),
+		{Header: "NOTE", Value: func(item any) string { return "a|b\nc" }},
+	}
+	// Act
+	output := printList(t, "markdown", columns...)
+	// Assert
+	autopilot.Equals(t, "| NAME | NOTE |\n| --- | --- |\n| Cart | a\\|b<br>c |\n| Search | a\\|b<br>c |\n", output)
+}
+
+func TestPrinterTSVIndexedPath(t *testing.T) {
+	// Arrange
+	// Act
+	output := printList(t, "tsv", common.NewColumn("ALIAS", "aliases.1"))
+	// Assert
+	autopilot.Equals(t, "ALIAS\nshopping-cart\n\n", output)
+}
+
+func TestPrinterJq(t *testing.T) {
+	// Arrange
+	// Act
+	output := printList(t, "jq=.[].owner.alias")
+	// Assert
+	autopilot.Equals(t, "platform\ndiscovery\n", output)
+}
+
+func TestPrinterJsonPath(t *testing.T) {
+	// Arrange
+	// Act
+	output := printList(t, "jsonpath={[*].name}")
+	// Assert
+	autopilot.Equals(t, "Cart\nSearch\n", output)
+}
+
+func TestPrinterTemplate(t *testing.T) {
+	// Arrange
+	// Act
+	output := printList(t, "template={{range .}}{{.name}}={{.index}};{{end}}")
+	// Assert
+	autopilot.Equals(t, "Cart=1;Search=2;", output)
+}
+
+func TestPrinterCustomColumns(t *testing.T) {
+	// Arrange
+	// Act
+	output := printList(t, "custom-columns=N:.name,O:owner.alias")
+	// Assert
+	autopilot.Equals(t, "N       O          \nCart    platform   \nSearch  discovery  \n", output)
+}
+
+func TestPrinterWideColumns(t *testing.T) {
+	// Arrange
+	var b bytes.Buffer
+	printer := common.NewPrinter("csv", common.NewColumn("NAME", "name"), common.NewWideColumn("OWNER", "owner.alias"))
+	printer.Out = &b
+	// Act
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	printer.Wide = true
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	// Assert
+	autopilot.Equals(t, "NAME\nCart\nSearch\nNAME,OWNER\nCart,platform\nSearch,discovery\n", b.String())
+}
+
+func TestPrinterSelectColumns(t *testing.T) {
+	// Arrange
+	var b bytes.Buffer
+	printer := common.NewPrinter("csv", common.NewColumn("NAME", "name"), common.NewColumn("IDX", "index"))
+	printer.Select = []string{"idx", "owner.alias", "First:aliases.0"}
+	printer.Out = &b
+	// Act
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	// Assert
+	autopilot.Equals(t, "IDX,OWNER_ALIAS,First\n1,platform,cart\n2,discovery,\n", b.String())
+}
+
+func TestPrinterSortBy(t *testing.T) {
+	// Arrange
+	var b bytes.Buffer
+	printer := common.NewPrinter("csv", common.NewColumn("NAME", "name"))
+	printer.SortBy = "owner.alias"
+	printer.Out = &b
+	// Act
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	printer.Format = "jq=.[].name"
+	printer.SortBy = "NAME"
+	autopilot.Ok(t, printer.PrintList(printerItems))
+	// Assert
+	autopilot.Equals(t, "NAME\nSearch\nCart\nCart\nSearch\n", b.String())
+}
+
+func TestPrinterUnknownFormat(t *testing.T) {
+	// Arrange
+	printer := common.NewPrinter("xml")
+	// Act
+	err := printer.PrintList(printerItems)
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown format")
+}
+
+func TestJsonPathToJq(t *testing.T) {
+	// Arrange
+	// Act
+	// Assert
+	autopilot.Equals(t, ".items[].name", common.JsonPathToJq("{.items[*].name}"))
+	autopilot.Equals(t, ".[].name", common.JsonPathToJq("{[*].name}"))
+	autopilot.Equals(t, ".name", common.JsonPathToJq("$.name"))
+}
+
+func TestIsTabularOutput(t *testing.T) {
+	// Arrange
+	// Act
+	// Assert
+	autopilot.Equals(t, true, common.IsTabularOutput("text"))
+	autopilot.Equals(t, true, common.IsTabularOutput("custom-columns=NAME:name"))
+	autopilot.Equals(t, false, common.IsTabularOutput("json"))
+	autopilot.Equals(t, false, common.IsTabularOutput("jq=.[].name"))
+}
@@ -0,0 +1,345 @@
+package common
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// RecordFormats are the file formats accepted by ReadRecordsFile
+const RecordFormats = "csv|json|ndjson|yaml"
+
+// RecordReader reads the rows of an import one at a time regardless of the file format
+type RecordReader interface {
+	// Rows advances to the next row and is false once there are no more rows
+	Rows() bool
+	// Text returns the value of the current row for the header or an empty string
+	Text(header string) string
+	// HeaderNames returns every header in the order they were first seen
+	HeaderNames() []string
+	// Values returns the current row in the same order as HeaderNames
+	Values() []string
+	// IsBlank is true when every value of the current row is empty
+	IsBlank() bool
+	Close() error
+}
+
+// Record is a single row of a RecordReader that stays valid after the reader moves on
+type Record struct {
+	// Line is the line of the row in a CSV file, where the header is line 1, or the
+	// 1-based index of the record in a JSON or YAML file
+	Line    int
+	headers []string
+	values  []string
+}
+
+// ReadAllRecords reads every remaining row of the reader so the rows can be processed concurrently
+func ReadAllRecords(reader RecordReader) []Record {
+	var output []Record
+	headers := reader.HeaderNames()
+	start := 2
+	if _, ok := reader.(*MapReader); ok {
+		start = 1
+	}
+	for line := start; reader.Rows(); line++ {
+		output = append(output, Record{Line: line, headers: headers, values: slices.Clone(reader.Values())})
+	}
+	return output
+}
+
+// Text returns the value for the header, matched exactly first and then case-insensitively
+func (r Record) Text(header string) string {
+	index := slices.Index(r.headers, header)
+	if index < 0 {
+		index = slices.IndexFunc(r.headers, func(item string) bool { return strings.EqualFold(item, header) })
+	}
+	if index < 0 || index >= len(r.values) {
+		return ""
+	}
+	return r.values[index]
+}
+
+func (r Record) HeaderNames() []string {
+	return r.headers
+}
+
+func (r Record) Values() []string {
+	return r.values
+}
+
+func (r Record) IsBlank() bool {
+	for _, value := range r.values {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+// MapReader is a RecordReader over records decoded from JSON or YAML
+//
+// Nested objects are flattened into 'key:subkey' headers so that '{"tag": {"env": "prod"}}'
+// is read the same as a 'tag:env' CSV column, lists are joined with ','.
+// Headers are matched case-insensitively since JSON and YAML keys are usually camel case.
+type MapReader struct {
+	headers []string
+	records []map[string]string
+	index   int
+	Row     map[string]string
+}
+
+func NewMapReader(records []map[string]any) *MapReader {
+	output := &MapReader{index: -1}
+	for _, record := range records {
+		row := map[string]string{}
+		flattenRecord(row, "", record)
+		for _, header := range slices.Sorted(maps.Keys(row)) {
+			if !slices.Contains(output.headers, header) {
+				output.headers = append(output.headers, header)
+			}
+		}
+		output.records = append(output.records, row)
+	}
+	return output
+}
+
+func (s *MapReader) Rows() bool {
+	s.index++
+	if s.index >= len(s.records) {
+		s.Row = nil
+		return false
+	}
+	s.Row = s.records[s.index]
+	return true
+}
+
+func (s *MapReader) Text(header string) string {
+	if value, ok := s.Row[header]; ok {
+		return value
+	}
+	for key, value := range s.Row {
+		if strings.EqualFold(key, header) {
+			return value
+		}
+	}
+	return ""
+}
+
+func (s *MapReader) HeaderNames() []string {
+	return s.headers
+}
+
+func (s *MapReader) Values() []string {
+	output := make([]string, len(s.headers))
+	for i, header := range s.headers {
+		output[i] = s.Row[header]
+	}
+	return output
+}
+
+func (s *MapReader) IsBlank() bool {
+	for _, value := range s.Row {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+	return true
+}
+
+func (s *MapReader) Close() error {
+	return nil
+}
+
+func flattenRecord(row map[string]string, prefix string, value any) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, item := range v {
+			if prefix != "" {
+				key = prefix + ":" + key
+			}
+			flattenRecord(row, key, item)
+		}
+	case []any:
+		var items []string
+		for _, item := range v {
+			items = append(items, formatRecordValue(item))
+		}
+		row[prefix] = strings.Join(items, ",")
+	default:
+		row[prefix] = formatRecordValue(v)
+	}
+}
+
+func formatRecordValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case map[string]any, []any:
+		data, _ := json.Marshal(v)
+		return string(data)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// ReadRecordsFile reads a CSV, JSON, NDJSON or YAML file where '-' is stdin
+//
+// When format is empty it is detected from the file extension or else from the content.
+// JSON files can hold a single object, a list of objects or one object per line, YAML
+// files can hold a list of objects or multiple documents.
+func ReadRecordsFile(filePath string, format string) (RecordReader, error) {
+	var data []byte
+	var err error
+	if filePath == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read file '%s' : %s", filePath, err)
+	}
+	if format == "" {
+		format = DetectRecordFormat(filePath, data)
+	}
+	var records []map[string]any
+	switch strings.ToLower(format) {
+	case "csv":
+		return newCSVReader(nil, bytes.NewReader(data), filePath)
+	case "json", "ndjson", "jsonl":
+		records, err = decodeJsonRecords(data)
+	case "yaml", "yml":
+		records, err = decodeYamlRecords(data)
+	default:
+		return nil, fmt.Errorf("unknown format '%s' - must be one of: %s", format, RecordFormats)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed reading file '%s' : %w", filePath, err)
+	}
+	return NewMapReader(records), nil
+}
+
+// DetectRecordFormat picks the format from the file extension and falls back to sniffing the content
+//
+// Content is only JSON or YAML when it decodes to objects in that format, anything else is
+// read as CSV since a CSV cell can hold '{', '- ' or ': ' as well.
+func DetectRecordFormat(filePath string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		return "csv"
+	case ".json":
+		return "json"
+	case ".ndjson", ".jsonl":
+		return "ndjson"
+	case ".yaml", ".yml":
+		return "yaml"
+	}
+	content := bytes.TrimSpace(data)
+	if len(content) == 0 {
+		return "csv"
+	}
+	if records, err := decodeJsonRecords(content); err == nil && len(records) > 0 {
+		return "json"
+	}
+	if records, err := decodeYamlRecords(content); err == nil && len(records) > 0 {
+		return "yaml"
+	}
+	return "csv"
+}
+
+// decodeJsonRecords reads every JSON value in the data so a list, a single object and NDJSON are all accepted
+func decodeJsonRecords(data []byte) ([]map[string]any, error) {
+	var output []map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	for {
+		var value any
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		records, err := toRecords(normalizeJsonNumbers(value))
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, records...)
+	}
+}
+
+func decodeYamlRecords(data []byte) ([]map[string]any, error) {
+	var output []map[string]any
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var value any
+		err := decoder.Decode(&value)
+		if errors.Is(err, io.EOF) {
+			return output, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if value == nil {
+			continue
+		}
+		records, err := toRecords(value)
+		if err != nil {
+			return nil, err
+		}
+		output = append(output, records...)
+	}
+}
+
+func toRecords(value any) ([]map[string]any, error) {
+	switch v := value.(type) {
+	case map[string]any:
+		return []map[string]any{v}, nil
+	case []any:
+		var output []map[string]any
+		for _, item := range v {
+			record, ok := item.(map[string]any)
+			if !ok {
+				return nil, fmt.Errorf("expected a list of objects but found '%v'", item)
+			}
+			output = append(output, record)
+		}
+		return output, nil
+	default:
+		return nil, fmt.Errorf("expected an object or a list of objects but found '%v'", value)
+	}
+}
+
+// normalizeJsonNumbers keeps large integers like IDs intact instead of converting them to float64
+func normalizeJsonNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeJsonNumbers(item)
+		}
+		return v
+	case []any:
+		for i, item := range v {
+			v[i] = normalizeJsonNumbers(item)
+		}
+		return v
+	default:
+		return value
+	}
+}
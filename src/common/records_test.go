@@ -0,0 +1,117 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func readRecords(t *testing.T, filename string, content string, format string) common.RecordReader {
+	path := filepath.Join(t.TempDir(), filename)
+	autopilot.Ok(t, os.WriteFile(path, []byte(content), 0o644))
+	reader, err := common.ReadRecordsFile(path, format)
+	autopilot.Ok(t, err)
+	return reader
+}
+
+func TestReadRecordsCSV(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "teams.csv", "Name,ParentTeam\nPlatform,engineering\n", "")
+	// Act
+	ok := reader.Rows()
+	// Assert
+	autopilot.Assert(t, ok, "expected a row")
+	autopilot.Equals(t, "engineering", reader.Text("parentTeam"))
+	autopilot.Equals(t, "", reader.Text("Missing"))
+	autopilot.Equals(t, []string{"Name", "ParentTeam"}, reader.HeaderNames())
+}
+
+func TestReadRecordsJSON(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "services", `[{"name": "Cart", "aliases": ["cart", "shopping"], "tag": {"env": "prod"}, "id": 12345678901234567890}]`, "")
+	// Act
+	ok := reader.Rows()
+	// Assert
+	autopilot.Assert(t, ok, "expected a row")
+	autopilot.Equals(t, "Cart", reader.Text("Name"))
+	autopilot.Equals(t, "cart,shopping", reader.Text("Aliases"))
+	autopilot.Equals(t, "prod", reader.Text("tag:env"))
+	autopilot.Equals(t, "12345678901234567890", reader.Text("id"))
+	autopilot.Equals(t, false, reader.Rows())
+}
+
+func TestReadRecordsNDJSON(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "users.ndjson", "{\"name\": \"Kyle\"}\n{\"name\": \"Edgar\", \"team\": \"platform\"}\n", "")
+	var names []string
+	// Act
+	for reader.Rows() {
+		names = append(names, reader.Text("Name"))
+	}
+	// Assert
+	autopilot.Equals(t, []string{"Kyle", "Edgar"}, names)
+	autopilot.Equals(t, []string{"name", "team"}, reader.HeaderNames())
+}
+
+func TestReadRecordsYAML(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "teams", "- name: Platform\n  enabled: true\n- name: \"\"\n", "yaml")
+	// Act
+	reader.Rows()
+	enabled := reader.Text("enabled")
+	reader.Rows()
+	// Assert
+	autopilot.Equals(t, "true", enabled)
+	autopilot.Assert(t, reader.IsBlank(), "expected the second row to be blank")
+}
+
+func TestReadRecordsUnknownFormat(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "teams.csv")
+	autopilot.Ok(t, os.WriteFile(path, []byte("Name\n"), 0o644))
+	// Act
+	_, err := common.ReadRecordsFile(path, "xml")
+	// Assert
+	autopilot.Assert(t, err != nil, "expected an error for an unknown format")
+}
+
+func TestDetectRecordFormat(t *testing.T) {
+	// Arrange
+	// Act
+	// Assert
+	autopilot.Equals(t, "json", common.DetectRecordFormat("-", []byte(" [{}]")))
+	autopilot.Equals(t, "json", common.DetectRecordFormat("-", []byte("{}\n{}")))
+	autopilot.Equals(t, "yaml", common.DetectRecordFormat("-", []byte("- name: Platform")))
+	autopilot.Equals(t, "csv", common.DetectRecordFormat("-", []byte("Name,Email\n")))
+	autopilot.Equals(t, "csv", common.DetectRecordFormat("-", []byte("Name,Description\nPlatform,Owns: the platform\n")))
+	autopilot.Equals(t, "csv", common.DetectRecordFormat("-", []byte("- Name,Email\n")))
+	autopilot.Equals(t, "csv", common.DetectRecordFormat("-", []byte("{Name},Email\n")))
+	autopilot.Equals(t, "ndjson", common.DetectRecordFormat("users.jsonl", nil))
+}
+
+func TestReadAllRecords(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "teams.csv", "Name,Manager\nPlatform,kyle@opslevel.com\n,\n", "")
+	// Act
+	records := common.ReadAllRecords(reader)
+	// Assert
+	autopilot.Equals(t, 2, len(records))
+	autopilot.Equals(t, 2, records[0].Line)
+	autopilot.Equals(t, "kyle@opslevel.com", records[0].Text("manager"))
+	autopilot.Equals(t, []string{"Platform", "kyle@opslevel.com"}, records[0].Values())
+	autopilot.Assert(t, records[1].IsBlank(), "expected the second record to be blank")
+}
+
+func TestReadAllRecordsIndex(t *testing.T) {
+	// Arrange
+	reader := readRecords(t, "teams.yaml", "- name: Platform\n  manager: kyle@opslevel.com\n- name: Payments\n", "")
+	// Act
+	records := common.ReadAllRecords(reader)
+	// Assert
+	autopilot.Equals(t, 2, len(records))
+	autopilot.Equals(t, 1, records[0].Line)
+	autopilot.Equals(t, 2, records[1].Line)
+}
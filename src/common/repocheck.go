@@ -0,0 +1,180 @@
+package common
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// RepoCheckResult is the outcome of evaluating a repository check against a local working tree
+type RepoCheckResult struct {
+	Passed bool
+	Reason string
+}
+
+// RepoRoot returns the directory that the paths of a repository check are relative to
+//
+// Like OpsLevel the paths start at the base directory of the service in the repository unless the
+// check uses the absolute root of the repository.
+func RepoRoot(checkout string, baseDirectory string, useAbsoluteRoot bool) string {
+	baseDirectory = strings.Trim(baseDirectory, "/")
+	if useAbsoluteRoot || baseDirectory == "" {
+		return checkout
+	}
+	return filepath.Join(checkout, filepath.FromSlash(baseDirectory))
+}
+
+// EvaluateRepoFile checks that one of the paths exists, and when a predicate is given that its contents satisfy it
+//
+// With directorySearch the paths must be directories and the predicate is not used.
+// Paths are relative to root and can be glob patterns.
+func EvaluateRepoFile(root string, paths []string, directorySearch bool, predicate *Predicate) (RepoCheckResult, error) {
+	var found []string
+	for _, path := range paths {
+		matches, err := globRepo(root, path)
+		if err != nil {
+			return RepoCheckResult{}, err
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			if err == nil && info.IsDir() == directorySearch {
+				found = append(found, match)
+			}
+		}
+	}
+	kind := "file"
+	if directorySearch {
+		kind = "directory"
+	}
+	if len(found) == 0 {
+		return RepoCheckResult{Reason: fmt.Sprintf("no %s found at %s", kind, quoteList(paths))}, nil
+	}
+	if predicate == nil || directorySearch {
+		return RepoCheckResult{Passed: true, Reason: fmt.Sprintf("found %s '%s'", kind, relativePath(root, found[0]))}, nil
+	}
+	return evaluateFiles(root, found, *predicate)
+}
+
+// EvaluateRepoGrep checks the contents of the files at the paths, or of every file under them with directorySearch
+func EvaluateRepoGrep(root string, paths []string, directorySearch bool, predicate Predicate) (RepoCheckResult, error) {
+	var files []string
+	for _, path := range paths {
+		matches, err := globRepo(root, path)
+		if err != nil {
+			return RepoCheckResult{}, err
+		}
+		for _, match := range matches {
+			info, err := os.Stat(match)
+			switch {
+			case err != nil:
+				continue
+			case info.IsDir() && directorySearch:
+				nested, err := walkRepo(match, func(string) bool { return true })
+				if err != nil {
+					return RepoCheckResult{}, err
+				}
+				files = append(files, nested...)
+			case !info.IsDir():
+				files = append(files, match)
+			}
+		}
+	}
+	return evaluateFiles(root, files, predicate)
+}
+
+// EvaluateRepoSearch checks the contents of every file in the repository with one of the extensions, or every file without extensions
+func EvaluateRepoSearch(root string, extensions []string, predicate Predicate) (RepoCheckResult, error) {
+	files, err := walkRepo(root, func(path string) bool {
+		return len(extensions) == 0 || slices.ContainsFunc(extensions, func(extension string) bool {
+			return strings.EqualFold(filepath.Ext(path), "."+strings.TrimPrefix(extension, "."))
+		})
+	})
+	if err != nil {
+		return RepoCheckResult{}, err
+	}
+	return evaluateFiles(root, files, predicate)
+}
+
+// evaluateFiles passes when any file satisfies the predicate, or every file for negative predicates like 'does_not_contain'
+func evaluateFiles(root string, files []string, predicate Predicate) (RepoCheckResult, error) {
+	description := describePredicate(predicate)
+	if len(files) == 0 {
+		passed, err := predicate.Evaluate(nil)
+		return RepoCheckResult{Passed: passed, Reason: "no matching files were found"}, err
+	}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return RepoCheckResult{}, err
+		}
+		content := string(data)
+		passed, err := predicate.Evaluate(&content)
+		if err != nil {
+			return RepoCheckResult{}, err
+		}
+		switch {
+		case predicate.IsNegative() && !passed:
+			return RepoCheckResult{Reason: fmt.Sprintf("'%s' does not satisfy %s", relativePath(root, file), description)}, nil
+		case !predicate.IsNegative() && passed:
+			return RepoCheckResult{Passed: true, Reason: fmt.Sprintf("'%s' satisfies %s", relativePath(root, file), description)}, nil
+		}
+	}
+	if predicate.IsNegative() {
+		return RepoCheckResult{Passed: true, Reason: fmt.Sprintf("all %d files satisfy %s", len(files), description)}, nil
+	}
+	return RepoCheckResult{Reason: fmt.Sprintf("none of %d files satisfy %s", len(files), description)}, nil
+}
+
+func globRepo(root string, path string) ([]string, error) {
+	matches, err := filepath.Glob(filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, "/"))))
+	if err != nil {
+		return nil, fmt.Errorf("invalid path '%s': %w", path, err)
+	}
+	return matches, nil
+}
+
+// walkRepo lists the files under dir that match, skipping the .git directory
+func walkRepo(dir string, match func(path string) bool) ([]string, error) {
+	var output []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if entry.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if entry.Type().IsRegular() && match(path) {
+			output = append(output, path)
+		}
+		return nil
+	})
+	return output, err
+}
+
+func describePredicate(predicate Predicate) string {
+	if predicate.Value == "" {
+		return fmt.Sprintf("'%s'", predicate.Type)
+	}
+	return fmt.Sprintf("'%s %s'", predicate.Type, predicate.Value)
+}
+
+func relativePath(root string, path string) string {
+	if relative, err := filepath.Rel(root, path); err == nil {
+		return filepath.ToSlash(relative)
+	}
+	return path
+}
+
+func quoteList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("'%s'", value)
+	}
+	return strings.Join(quoted, ", ")
+}
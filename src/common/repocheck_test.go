@@ -0,0 +1,87 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+func writeRepo(t *testing.T, files map[string]string) string {
+	root := t.TempDir()
+	for path, content := range files {
+		fullPath := filepath.Join(root, filepath.FromSlash(path))
+		autopilot.Ok(t, os.MkdirAll(filepath.Dir(fullPath), 0o755))
+		autopilot.Ok(t, os.WriteFile(fullPath, []byte(content), 0o644))
+	}
+	return root
+}
+
+func TestEvaluateRepoFile(t *testing.T) {
+	// Arrange
+	root := writeRepo(t, map[string]string{
+		"Dockerfile":    "FROM golang:1.24",
+		"docs/index.md": "# Docs",
+	})
+	// Act
+	exists, err := common.EvaluateRepoFile(root, []string{"/Dockerfile"}, false, nil)
+	autopilot.Ok(t, err)
+	contents, err := common.EvaluateRepoFile(root, []string{"Dockerfile"}, false, &common.Predicate{Type: "contains", Value: "alpine"})
+	autopilot.Ok(t, err)
+	directory, err := common.EvaluateRepoFile(root, []string{"docs"}, true, nil)
+	autopilot.Ok(t, err)
+	missing, err := common.EvaluateRepoFile(root, []string{"README.md", "*.txt"}, false, nil)
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, common.RepoCheckResult{Passed: true, Reason: "found file 'Dockerfile'"}, exists)
+	autopilot.Equals(t, common.RepoCheckResult{Reason: "none of 1 files satisfy 'contains alpine'"}, contents)
+	autopilot.Equals(t, true, directory.Passed)
+	autopilot.Equals(t, "no file found at 'README.md', '*.txt'", missing.Reason)
+}
+
+func TestEvaluateRepoGrep(t *testing.T) {
+	// Arrange
+	root := writeRepo(t, map[string]string{
+		"config/app.yaml":    "replicas: 3",
+		"config/worker.yaml": "replicas: 1\ndebug: true",
+	})
+	// Act
+	anyFile, err := common.EvaluateRepoGrep(root, []string{"config"}, true, common.Predicate{Type: "contains", Value: "replicas: 3"})
+	autopilot.Ok(t, err)
+	everyFile, err := common.EvaluateRepoGrep(root, []string{"config/*.yaml"}, false, common.Predicate{Type: "does_not_contain", Value: "debug"})
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, common.RepoCheckResult{Passed: true, Reason: "'config/app.yaml' satisfies 'contains replicas: 3'"}, anyFile)
+	autopilot.Equals(t, common.RepoCheckResult{Reason: "'config/worker.yaml' does not satisfy 'does_not_contain debug'"}, everyFile)
+}
+
+func TestEvaluateRepoSearch(t *testing.T) {
+	// Arrange
+	root := writeRepo(t, map[string]string{
+		"main.go":      "package main // TODO",
+		"lib/util.go":  "package lib",
+		"README.md":    "TODO",
+		".git/HEAD.go": "TODO",
+	})
+	// Act
+	result, err := common.EvaluateRepoSearch(root, []string{"go"}, common.Predicate{Type: "does_not_contain", Value: "TODO"})
+	autopilot.Ok(t, err)
+	none, err := common.EvaluateRepoSearch(root, []string{".py"}, common.Predicate{Type: "contains", Value: "import"})
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, common.RepoCheckResult{Reason: "'main.go' does not satisfy 'does_not_contain TODO'"}, result)
+	autopilot.Equals(t, common.RepoCheckResult{Reason: "no matching files were found"}, none)
+}
+
+func TestRepoRoot(t *testing.T) {
+	// Arrange
+	checkout := filepath.Join("src", "monorepo")
+	// Act
+	// Assert
+	autopilot.Equals(t, checkout, common.RepoRoot(checkout, "", false))
+	autopilot.Equals(t, checkout, common.RepoRoot(checkout, "/", false))
+	autopilot.Equals(t, filepath.Join(checkout, "services", "cart"), common.RepoRoot(checkout, "/services/cart/", false))
+	autopilot.Equals(t, checkout, common.RepoRoot(checkout, "services/cart", true))
+}
@@ -0,0 +1,91 @@
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Spool is a directory of JSON files that are read back in the order they were written
+type Spool struct {
+	Dir string
+}
+
+// Push writes the value as the last entry of the spool and returns its path
+//
+// The entry is written to a temporary file first so a reader never sees a partial entry.
+func (s Spool) Push(value any) (string, error) {
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(s.Dir, 0o700); err != nil {
+		return "", err
+	}
+	file, err := os.CreateTemp(s.Dir, fmt.Sprintf("%020d-*.tmp", time.Now().UnixNano()))
+	if err != nil {
+		return "", err
+	}
+	_, err = file.Write(data)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	path := strings.TrimSuffix(file.Name(), ".tmp") + ".json"
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return "", err
+	}
+	return path, nil
+}
+
+// Entries returns the paths of the entries oldest first, a spool that does not exist yet is empty
+func (s Spool) Entries() ([]string, error) {
+	files, err := os.ReadDir(s.Dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var paths []string
+	for _, file := range files {
+		if !file.IsDir() && filepath.Ext(file.Name()) == ".json" {
+			paths = append(paths, filepath.Join(s.Dir, file.Name()))
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// Read decodes the entry at path into value
+func (s Spool) Read(path string, value any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to read spool entry '%s': %w", path, err)
+	}
+	return nil
+}
+
+// Remove deletes an entry once it has been replayed
+func (s Spool) Remove(path string) error {
+	return os.Remove(path)
+}
+
+// Reject keeps an entry that can never be replayed for inspection without returning it from Entries
+func (s Spool) Reject(path string) (string, error) {
+	rejected := strings.TrimSuffix(path, ".json") + ".failed"
+	return rejected, os.Rename(path, rejected)
+}
@@ -0,0 +1,60 @@
+package common_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/opslevel/cli/common"
+	"github.com/rocktavious/autopilot"
+)
+
+type spoolEntry struct {
+	Id string `json:"id"`
+}
+
+func TestSpoolEntriesInOrder(t *testing.T) {
+	// Arrange
+	spool := common.Spool{Dir: filepath.Join(t.TempDir(), "spool")}
+	for _, id := range []string{"first", "second", "third"} {
+		_, err := spool.Push(spoolEntry{Id: id})
+		autopilot.Ok(t, err)
+	}
+	// Act
+	paths, err := spool.Entries()
+	autopilot.Ok(t, err)
+	var ids []string
+	for _, path := range paths {
+		var entry spoolEntry
+		autopilot.Ok(t, spool.Read(path, &entry))
+		ids = append(ids, entry.Id)
+	}
+	// Assert
+	autopilot.Equals(t, []string{"first", "second", "third"}, ids)
+}
+
+func TestSpoolMissingDir(t *testing.T) {
+	// Arrange
+	spool := common.Spool{Dir: filepath.Join(t.TempDir(), "missing")}
+	// Act
+	paths, err := spool.Entries()
+	// Assert
+	autopilot.Ok(t, err)
+	autopilot.Equals(t, 0, len(paths))
+}
+
+func TestSpoolReject(t *testing.T) {
+	// Arrange
+	spool := common.Spool{Dir: t.TempDir()}
+	path, err := spool.Push(spoolEntry{Id: "bad"})
+	autopilot.Ok(t, err)
+	// Act
+	rejected, err := spool.Reject(path)
+	autopilot.Ok(t, err)
+	paths, err := spool.Entries()
+	autopilot.Ok(t, err)
+	// Assert
+	autopilot.Equals(t, 0, len(paths))
+	_, err = os.Stat(rejected)
+	autopilot.Ok(t, err)
+}